@@ -10,6 +10,7 @@ require (
 	github.com/graphql-go/handler v0.2.4
 	github.com/lib/pq v1.10.9
 	github.com/mmcdole/gofeed v1.3.0
+	golang.org/x/net v0.45.0
 )
 
 require (
@@ -18,6 +19,5 @@ require (
 	github.com/mmcdole/goxpp v1.1.1-0.20240225020742-a0c311522b23 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
-	golang.org/x/net v0.45.0 // indirect
 	golang.org/x/text v0.30.0 // indirect
 )
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/geraldfingburke/dossier/server/internal/database"
+	"github.com/geraldfingburke/dossier/server/internal/email"
+	"github.com/geraldfingburke/dossier/server/internal/models"
+)
+
+// ============================================================================
+// COMMAND-LINE SUBCOMMANDS
+// ============================================================================
+//
+// Running the binary with no arguments (or "serve") starts the HTTP server and
+// the built-in scheduler. The subcommands below initialize the same services,
+// perform one task, print the outcome, and exit - suitable for cron jobs,
+// scripts, and debugging without a long-running process.
+//
+//	dossier generate --config-id N [--force]
+//	dossier test-email --config-id N
+//	dossier test-smtp
+//
+// Exit codes: 0 on success, 1 on failure, 2 on invalid usage.
+
+// usage is printed for unknown subcommands and -h.
+const usage = `Usage: dossier [command] [flags]
+
+Commands:
+  serve                      Start the HTTP server and scheduler (default)
+  generate --config-id N     Generate and send one dossier, then exit
+  test-email --config-id N   Send a sample dossier to the config's recipient
+  test-smtp                  Connect and authenticate to the SMTP server
+`
+
+// runCommand dispatches a subcommand and returns the process exit code.
+//
+// Parameters:
+//   - name: Subcommand name (first command-line argument)
+//   - args: Remaining arguments, parsed as the subcommand's flags
+//
+// Returns:
+//   - int: Exit code
+func runCommand(name string, args []string) int {
+	switch name {
+	case "generate":
+		return runGenerate(args)
+	case "test-email":
+		return runTestEmail(args)
+	case "test-smtp":
+		return runTestSMTP(args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", name, usage)
+		return 2
+	}
+}
+
+// runGenerate runs the full generation pipeline for one configuration, the
+// same way a scheduled run would, and reports the resulting delivery.
+//
+// Flags:
+//   - --config-id: Configuration ID to generate (required)
+//   - --force: Bypass the AI summary cache
+//
+// Parameters:
+//   - args: Command-line flags
+//
+// Returns:
+//   - int: Exit code
+func runGenerate(args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	configID := flags.Int("config-id", 0, "dossier configuration ID to generate (required)")
+	force := flags.Bool("force", false, "regenerate even if an identical summary is cached")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *configID <= 0 {
+		fmt.Fprintln(os.Stderr, "generate: --config-id is required")
+		flags.Usage()
+		return 2
+	}
+
+	svc := initServices()
+	defer svc.db.Close()
+
+	config, err := loadConfig(context.Background(), svc.db, *configID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generate: %v\n", err)
+		return 1
+	}
+
+	// Remember the newest existing delivery so the one this run records can
+	// be told apart from earlier ones
+	var lastDeliveryID int
+	if err := svc.db.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM dossier_deliveries WHERE config_id = $1`,
+		config.ID).Scan(&lastDeliveryID); err != nil {
+		fmt.Fprintf(os.Stderr, "generate: %v\n", err)
+		return 1
+	}
+
+	started := time.Now()
+	if err := svc.scheduler.GenerateNow(*config, *force); err != nil {
+		fmt.Fprintf(os.Stderr, "generate: config %d (%s) failed: %v\n", config.ID, config.Title, err)
+		return 1
+	}
+
+	// The pipeline records every outcome, including deliberate skips, so the
+	// newest delivery row tells us what actually happened
+	var deliveryID, articleCount int
+	var skipReason string
+	err = svc.db.QueryRow(`
+		SELECT id, article_count, COALESCE(skip_reason, '')
+		FROM dossier_deliveries
+		WHERE config_id = $1 AND id > $2
+		ORDER BY id DESC
+		LIMIT 1
+	`, config.ID, lastDeliveryID).Scan(&deliveryID, &articleCount, &skipReason)
+	switch {
+	case err == sql.ErrNoRows:
+		fmt.Printf("Config %d (%s): generation finished but no delivery was recorded\n", config.ID, config.Title)
+	case err != nil:
+		fmt.Printf("Config %d (%s): sent to %s (could not load delivery record: %v)\n", config.ID, config.Title, config.Email, err)
+	case skipReason != "":
+		fmt.Printf("Config %d (%s): skipped - %s (delivery %d)\n", config.ID, config.Title, skipReason, deliveryID)
+	default:
+		fmt.Printf("Config %d (%s): sent %d articles to %s (delivery %d, %s)\n",
+			config.ID, config.Title, articleCount, config.Email, deliveryID, time.Since(started).Round(time.Second))
+	}
+	return 0
+}
+
+// runTestEmail sends the same sample dossier as the sendTestEmail mutation to
+// a configuration's recipient, exercising templates and SMTP delivery without
+// fetching feeds or calling the AI service.
+//
+// Flags:
+//   - --config-id: Configuration ID to test (required)
+//
+// Parameters:
+//   - args: Command-line flags
+//
+// Returns:
+//   - int: Exit code
+func runTestEmail(args []string) int {
+	flags := flag.NewFlagSet("test-email", flag.ContinueOnError)
+	configID := flags.Int("config-id", 0, "dossier configuration ID whose recipient and settings to use (required)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *configID <= 0 {
+		fmt.Fprintln(os.Stderr, "test-email: --config-id is required")
+		flags.Usage()
+		return 2
+	}
+
+	svc := initServices()
+	defer svc.db.Close()
+
+	ctx := context.Background()
+	config, err := loadConfig(ctx, svc.db, *configID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "test-email: %v\n", err)
+		return 1
+	}
+	feedURLs, err := database.ResolveFeedURLs(ctx, svc.db, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "test-email: %v\n", err)
+		return 1
+	}
+
+	testConfig, content, articles := email.TestDossier(config, feedURLs, time.Now())
+	if err := svc.email.SendDossier(ctx, testConfig, content, articles); err != nil {
+		fmt.Fprintf(os.Stderr, "FAIL: test email for config %d (%s) to %s: %v\n", config.ID, config.Title, config.Email, err)
+		return 1
+	}
+
+	fmt.Printf("OK: test email for config %d (%s) sent to %s\n", config.ID, config.Title, config.Email)
+	return 0
+}
+
+// runTestSMTP verifies the SMTP settings (connection, TLS, and
+// authentication) without sending mail. It needs no database.
+//
+// Parameters:
+//   - args: Command-line flags (none accepted)
+//
+// Returns:
+//   - int: Exit code
+func runTestSMTP(args []string) int {
+	flags := flag.NewFlagSet("test-smtp", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := email.NewService().TestSMTPConnection(); err != nil {
+		fmt.Fprintf(os.Stderr, "FAIL: SMTP connection test: %v\n", err)
+		return 1
+	}
+
+	fmt.Println("OK: connected and authenticated to the SMTP server")
+	return 0
+}
+
+// loadConfig fetches a dossier configuration by ID.
+//
+// Parameters:
+//   - ctx: Context for the query
+//   - db: Database connection
+//   - id: Configuration ID
+//
+// Returns:
+//   - *models.DossierConfig: The configuration
+//   - error: Not found or query failure
+func loadConfig(ctx context.Context, db *sql.DB, id int) (*models.DossierConfig, error) {
+	var config models.DossierConfig
+	err := database.ScanConfig(db.QueryRowContext(ctx, `
+		SELECT `+database.ConfigColumns+`
+		FROM dossier_configs WHERE id = $1
+	`, id), &config)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("dossier configuration %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration %d: %w", id, err)
+	}
+	return &config, nil
+}
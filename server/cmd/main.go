@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"log"
 	"net/http"
 	"os"
@@ -20,16 +21,36 @@ import (
 	"github.com/go-chi/cors"
 )
 
-func main() {
+// Default HTTP server timeouts, overridable via HTTP_READ_TIMEOUT,
+// HTTP_WRITE_TIMEOUT, and HTTP_IDLE_TIMEOUT (Go duration strings, e.g. "45s").
+const (
+	defaultReadTimeout  = 30 * time.Second
+	defaultWriteTimeout = 10 * time.Minute // Covers a synchronous AI run
+	defaultIdleTimeout  = 60 * time.Second
+)
+
+// services bundles the application services shared by the HTTP server and
+// the command-line subcommands.
+type services struct {
+	db        *sql.DB
+	ai        *ai.Service
+	email     *email.Service
+	rss       *rss.Service
+	scheduler *scheduler.Service
+}
+
+// initServices connects to the database, runs migrations, and builds every
+// service. Any failure is fatal.
+func initServices() *services {
 	// Initialize database
 	db, err := database.NewDB()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer db.Close()
 
 	// Run migrations
 	if err := database.Migrate(db); err != nil {
+		db.Close()
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
@@ -37,7 +58,25 @@ func main() {
 	aiService := ai.NewService(db)
 	emailService := email.NewService()
 	rssService := rss.NewService(aiService)
-	schedulerService := scheduler.NewService(db, rssService, aiService, emailService)
+	return &services{
+		db:        db,
+		ai:        aiService,
+		email:     emailService,
+		rss:       rssService,
+		scheduler: scheduler.NewService(db, rssService, aiService, emailService),
+	}
+}
+
+func main() {
+	// Subcommands (generate, ...) run once and exit; no arguments or "serve"
+	// starts the HTTP server and scheduler
+	if len(os.Args) > 1 && os.Args[1] != "serve" {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+
+	svc := initServices()
+	defer svc.db.Close()
+	schedulerService := svc.scheduler
 
 	// Create router
 	r := chi.NewRouter()
@@ -55,11 +94,20 @@ func main() {
 	}))
 
 	// GraphQL handler
-	gqlHandler, err := graphql.Handler(db, rssService, aiService, emailService, schedulerService)
+	gqlHandler, err := graphql.Handler(svc.db, svc.rss, svc.ai, svc.email, schedulerService)
 	if err != nil {
 		log.Fatalf("Failed to create GraphQL handler: %v", err)
 	}
-	r.Handle("/graphql", gqlHandler)
+	r.Handle("/graphql", graphql.AdminMiddleware(gqlHandler))
+
+	// Browser preview of a config's rendered dossier email (admin only)
+	r.Method(http.MethodGet, "/preview/{configId}",
+		graphql.AdminMiddleware(graphql.PreviewHandler(svc.db, schedulerService, svc.ai, svc.email)))
+
+	// Signed unsubscribe and manage links from dossier emails. These carry
+	// their own authorization, so they sit outside AdminMiddleware.
+	r.Handle("/unsubscribe", graphql.UnsubscribeHandler(svc.db, svc.email))
+	r.Handle("/manage", graphql.ManageHandler(svc.db, svc.email))
 
 	// Health check
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -73,12 +121,17 @@ func main() {
 		port = "8080"
 	}
 
+	// The write timeout must cover a full AI run while clients (the bundled
+	// one included) call the synchronous generateAndSendDossier mutation: a
+	// run whose response is cut off still sends, so the client would report a
+	// failure for a dossier that was emailed. Deployments whose clients only
+	// use queueDossierGeneration can lower HTTP_WRITE_TIMEOUT.
 	srv := &http.Server{
 		Addr:         ":" + port,
 		Handler:      r,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 10 * time.Minute, // Extended for AI operations
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  durationFromEnv("HTTP_READ_TIMEOUT", defaultReadTimeout),
+		WriteTimeout: durationFromEnv("HTTP_WRITE_TIMEOUT", defaultWriteTimeout),
+		IdleTimeout:  durationFromEnv("HTTP_IDLE_TIMEOUT", defaultIdleTimeout),
 	}
 
 	// Start the dossier scheduler
@@ -111,3 +164,19 @@ func main() {
 
 	log.Println("Server exited")
 }
+
+// durationFromEnv parses a Go duration from the named environment variable,
+// falling back to the default when unset or invalid.
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid %s %q, using default %s", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
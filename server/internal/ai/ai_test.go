@@ -0,0 +1,322 @@
+package ai
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/geraldfingburke/dossier/server/internal/models"
+)
+
+// newTestService returns a Service whose Ollama API is served by respond,
+// which receives each /api/generate prompt and returns the response text.
+func newTestService(t *testing.T, respond func(prompt string) string) *Service {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OllamaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(OllamaResponse{Response: respond(req.Prompt), Done: true})
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("OLLAMA_URL", server.URL)
+	return NewService(nil)
+}
+
+// testArticles returns n distinct candidate articles, newest first.
+func testArticles(n int) []models.Article {
+	articles := make([]models.Article, n)
+	published := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+	for i := range articles {
+		articles[i] = models.Article{
+			Title:       fmt.Sprintf("Story %d", i+1),
+			Link:        fmt.Sprintf("https://example.com/story-%d", i+1),
+			Description: fmt.Sprintf("Description of story %d", i+1),
+			PublishedAt: published.Add(-time.Duration(i) * time.Hour),
+		}
+	}
+	return articles
+}
+
+// indexList returns "1,2,...,n", the selection response picking the first n.
+func indexList(n int) string {
+	indices := make([]string, n)
+	for i := range indices {
+		indices[i] = strconv.Itoa(i + 1)
+	}
+	return strings.Join(indices, ",")
+}
+
+func TestTargetCount(t *testing.T) {
+	tests := []struct {
+		count, candidates, want int
+	}{
+		{15, 20, 15},
+		{15, 15, 15},
+		{15, 12, 12},
+		{0, 20, defaultTargetArticleCount},
+		{0, 4, 4},
+	}
+	for _, tt := range tests {
+		if got := targetCount(tt.count, tt.candidates); got != tt.want {
+			t.Errorf("targetCount(%d, %d) = %d, want %d", tt.count, tt.candidates, got, tt.want)
+		}
+	}
+}
+
+func TestSelectArticlesHonorsArticleCount(t *testing.T) {
+	config := &models.DossierConfig{ArticleCount: 15}
+	opts := SummaryOptionsFromConfig(config)
+	if opts.ArticleCount != 15 {
+		t.Fatalf("SummaryOptionsFromConfig ArticleCount = %d, want 15", opts.ArticleCount)
+	}
+
+	tests := []struct {
+		name     string
+		response string
+	}{
+		{"model returns exactly the count", indexList(15)},
+		{"model over-selects", indexList(20)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prompt string
+			s := newTestService(t, func(p string) string {
+				prompt = p
+				return tt.response
+			})
+
+			candidates := testArticles(20)
+			if !s.selectionNeeded(len(candidates), opts.ArticleCount) {
+				t.Fatal("selection not needed for 20 candidates and an article count of 15")
+			}
+
+			selected, _, err := s.selectArticlesWithInstructions(context.Background(), candidates, opts.ArticleCount, "", "", false, false, false)
+			if err != nil {
+				t.Fatalf("selectArticlesWithInstructions returned error: %v", err)
+			}
+			if len(selected) != 15 {
+				t.Errorf("selected %d articles, want 15", len(selected))
+			}
+			if !strings.Contains(prompt, "select exactly 15") {
+				t.Errorf("selection prompt does not ask for 15 articles:\n%s", prompt)
+			}
+		})
+	}
+
+	t.Run("fallback after failed selection", func(t *testing.T) {
+		// processArticlesRobustly keeps the first targetCount candidates
+		candidates := testArticles(20)
+		if got := len(candidates[:targetCount(opts.ArticleCount, len(candidates))]); got != 15 {
+			t.Errorf("fallback keeps %d articles, want 15", got)
+		}
+	})
+}
+
+func TestSummaryCacheKey(t *testing.T) {
+	articles := testArticles(3)
+	opts := SummaryOptions{Tone: "professional", Language: "English", Timezone: "America/New_York"}
+	base := summaryCacheKey(articles, opts, "Write formally.")
+
+	if got := summaryCacheKey(articles, opts, "Write formally."); got != base {
+		t.Error("identical inputs produced different keys")
+	}
+	if got := summaryCacheKey(articles, opts, "Write formally and briefly."); got == base {
+		t.Error("editing the tone prompt did not change the key")
+	}
+	moved := opts
+	moved.Timezone = "Europe/London"
+	if got := summaryCacheKey(articles, moved, "Write formally."); got == base {
+		t.Error("changing the timezone did not change the key")
+	}
+}
+
+func TestGenerationTime(t *testing.T) {
+	runAt := time.Date(2025, time.March, 9, 7, 30, 0, 0, time.UTC)
+	if got := (SummaryOptions{GeneratedAt: runAt}).generationTime(); !got.Equal(runAt) {
+		t.Errorf("generationTime = %s, want the run time %s", got, runAt)
+	}
+
+	before := time.Now()
+	if got := (SummaryOptions{}).generationTime(); got.Before(before) {
+		t.Errorf("generationTime without GeneratedAt = %s, want the current time", got)
+	}
+}
+
+func TestStripRestatedTitle(t *testing.T) {
+	const title = "Solar Output Hits Record"
+	const body = "Grid operators reported a new peak on Sunday."
+
+	tests := []struct {
+		name    string
+		summary string
+		want    string
+	}{
+		{"plain title", title + "\n" + body, body},
+		{"heading title", "## " + title + "\n\n" + body, body},
+		{"bold title", "**" + title + "**\n" + body, body},
+		{"labelled title", "**Title:** " + title + "\n" + body, body},
+		{"quoted title, other case", `"solar output hits record"` + "\n" + body, body},
+		{"bold lead sentence kept", "**Record output.**\n" + body, "**Record output.**\n" + body},
+		{"heading of another title kept", "## Wind Farm Approved\n" + body, "## Wind Farm Approved\n" + body},
+		{"title only kept", "## " + title, "## " + title},
+		{"no title line", body, body},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripRestatedTitle(tt.summary, title); got != tt.want {
+				t.Errorf("stripRestatedTitle = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLToPlainText(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		maxLength int
+		want      string
+	}{
+		{"whitespace collapsed", "<p>One\n  two</p> <p>three</p>", 50, "One two three"},
+		{"ascii truncated", "<p>abcdefgh</p>", 5, "abcde..."},
+		{"multi-byte truncated on a rune", "<p>Überblick über München</p>", 7, "Überbli..."},
+		{"cjk truncated on a rune", "<p>東京の天気は晴れです</p>", 4, "東京の天..."},
+		{"short text kept", "<p>Ça va</p>", 10, "Ça va"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := htmlToPlainText(tt.html, tt.maxLength)
+			if got != tt.want {
+				t.Errorf("htmlToPlainText = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("htmlToPlainText returned invalid UTF-8 %q", got)
+			}
+		})
+	}
+}
+
+// gzipBytes returns text gzip-compressed.
+func gzipBytes(t *testing.T, text string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(text)); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodedTextBody(t *testing.T) {
+	const page = "<html><body><p>Gzip fixture article text.</p></body></html>"
+
+	var deflated bytes.Buffer
+	zw := zlib.NewWriter(&deflated)
+	zw.Write([]byte(page))
+	zw.Close()
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		wantErr  bool
+	}{
+		{"plain", "", []byte(page), false},
+		{"gzip", "gzip", gzipBytes(t, page), false},
+		{"x-gzip", "x-gzip", gzipBytes(t, page), false},
+		{"gzip without content encoding", "", gzipBytes(t, page), false},
+		{"deflate", "deflate", deflated.Bytes(), false},
+		{"corrupt gzip", "gzip", []byte("not gzip at all"), true},
+		{"unsupported encoding", "br", []byte(page), true},
+		{"binary", "", []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0, 0, 0, 0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{},
+				Body:   io.NopCloser(bytes.NewReader(tt.body)),
+			}
+			if tt.encoding != "" {
+				resp.Header.Set("Content-Encoding", tt.encoding)
+			}
+
+			reader, err := decodedTextBody(resp)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("decodedTextBody succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodedTextBody returned error: %v", err)
+			}
+			got, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("reading decoded body: %v", err)
+			}
+			if string(got) != page {
+				t.Errorf("decoded body = %q, want %q", got, page)
+			}
+		})
+	}
+}
+
+func TestDecodedTextBodyWithoutTransportDecompression(t *testing.T) {
+	const page = "<html><body><p>Served gzip-encoded.</p></body></html>"
+	compressed := gzipBytes(t, page)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed)
+	}))
+	defer server.Close()
+
+	// Asking for gzip explicitly turns off the transport's transparent
+	// decompression, as when a server compresses regardless of the request
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.Uncompressed {
+		t.Fatal("transport decompressed the body; the fixture must reach decodedTextBody compressed")
+	}
+
+	reader, err := decodedTextBody(resp)
+	if err != nil {
+		t.Fatalf("decodedTextBody returned error: %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("reading decoded body: %v", err)
+	}
+	if string(got) != page {
+		t.Errorf("decoded body = %q, want %q", got, page)
+	}
+}
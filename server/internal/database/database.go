@@ -5,11 +5,15 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
 
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/geraldfingburke/dossier/server/internal/models"
+	"github.com/lib/pq" // PostgreSQL driver
 )
 
 // ============================================================================
@@ -70,6 +74,296 @@ func NewDB() (*sql.DB, error) {
 	return db, nil
 }
 
+// Settings reports the effective database connection for the serverConfig
+// query, with the password masked. A DATABASE_URL in key=value form is not
+// shown, since it can't be masked reliably.
+//
+// Returns:
+//   - []models.Setting: The DATABASE_URL setting
+func Settings() []models.Setting {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		dbURL = defaultDatabaseURL
+	}
+	value := "(set, not shown: not a URL)"
+	if parsed, err := url.Parse(dbURL); err == nil && parsed.Scheme != "" {
+		value = parsed.Redacted()
+	}
+	return []models.Setting{{Name: "DATABASE_URL", Value: value}}
+}
+
+// ============================================================================
+// CONFIGURATION QUERIES
+// ============================================================================
+
+// ConfigColumns is the dossier_configs column list matching ScanConfig.
+//
+// Every query that loads a models.DossierConfig selects (or RETURNINGs) these
+// columns so new configuration fields only need to be added in one place.
+// delivery_time is cast to text so it scans as HH:MM:SS.
+const ConfigColumns = `id, title, email, feed_urls, article_count, frequency,
+	delivery_time::text, timezone, tone, language, special_instructions,
+	active, created_at, updated_at, rollup_source_id, interests,
+	enforce_language, include_executive_summary, include_conclusion,
+	skip_weekends, skip_dates, feed_ids, min_articles, delivery_weekdays,
+	max_per_source, cta_label, footer_text, articles_only_fallback,
+	order_by_importance, preserve_titles, attach_pdf, sandbox_sends,
+	pipeline, temperature, use_feed_content, split_by_category,
+	recipient_name, since_last_delivery, sponsored_filter, sponsored_patterns,
+	distribution, tone_intensity, candidate_pool_size`
+
+// RowScanner is satisfied by both *sql.Row and *sql.Rows.
+type RowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// ScanConfig scans a row selected with ConfigColumns into a DossierConfig.
+//
+// Parameters:
+//   - row: Row or result cursor positioned on a dossier_configs row
+//   - config: Destination configuration
+//
+// Returns:
+//   - error: Scan error (sql.ErrNoRows when a single-row query found nothing)
+//
+// Example:
+//
+//	var config models.DossierConfig
+//	err := database.ScanConfig(db.QueryRowContext(ctx,
+//	    `SELECT `+database.ConfigColumns+` FROM dossier_configs WHERE id = $1`, id), &config)
+func ScanConfig(row RowScanner, config *models.DossierConfig) error {
+	var rollupSourceID sql.NullInt64
+	var temperature sql.NullFloat64
+
+	err := row.Scan(
+		&config.ID, &config.Title, &config.Email, pq.Array(&config.FeedURLs),
+		&config.ArticleCount, &config.Frequency, &config.DeliveryTime,
+		&config.Timezone, &config.Tone, &config.Language,
+		&config.SpecialInstructions, &config.Active, &config.CreatedAt, &config.UpdatedAt,
+		&rollupSourceID, &config.Interests, &config.EnforceLanguage,
+		&config.IncludeExecutiveSummary, &config.IncludeConclusion, &config.SkipWeekends,
+		pq.Array(&config.SkipDates), pq.Array(&config.FeedIDs), &config.MinArticles,
+		pq.Array(&config.DeliveryWeekdays), &config.MaxPerSource,
+		&config.CTALabel, &config.FooterText, &config.ArticlesOnlyFallback,
+		&config.OrderByImportance, &config.PreserveTitles, &config.AttachPDF,
+		&config.SandboxSends, &config.Pipeline, &temperature, &config.UseFeedContent,
+		&config.SplitByCategory, &config.RecipientName, &config.SinceLastDelivery,
+		&config.SponsoredFilter, pq.Array(&config.SponsoredPatterns), &config.Distribution,
+		&config.ToneIntensity, &config.CandidatePoolSize,
+	)
+	if err != nil {
+		return err
+	}
+
+	config.RollupSourceID = nil
+	if rollupSourceID.Valid {
+		id := int(rollupSourceID.Int64)
+		config.RollupSourceID = &id
+	}
+
+	config.Temperature = nil
+	if temperature.Valid {
+		config.Temperature = &temperature.Float64
+	}
+	return nil
+}
+
+// ============================================================================
+// FEED QUERIES
+// ============================================================================
+
+// FeedColumns is the feeds column list matching ScanFeed. Nullable metadata
+// columns are coalesced so they scan into plain strings.
+const FeedColumns = `id, url, COALESCE(title, ''), COALESCE(description, ''),
+	COALESCE(category, ''), priority, active, last_fetched, created_at, updated_at`
+
+// ScanFeed scans a row selected with FeedColumns into a Feed.
+//
+// Parameters:
+//   - row: Row or result cursor positioned on a feeds row
+//   - feed: Destination feed
+//
+// Returns:
+//   - error: Scan error (sql.ErrNoRows when a single-row query found nothing)
+func ScanFeed(row RowScanner, feed *models.Feed) error {
+	var lastFetched sql.NullTime
+
+	err := row.Scan(&feed.ID, &feed.URL, &feed.Title, &feed.Description,
+		&feed.Category, &feed.Priority, &feed.Active, &lastFetched, &feed.CreatedAt, &feed.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	feed.LastFetched = nil
+	if lastFetched.Valid {
+		feed.LastFetched = &lastFetched.Time
+	}
+	return nil
+}
+
+// ResolveFeedURLs returns every feed URL a configuration aggregates.
+//
+// Raw URLs from FeedURLs come first, followed by the URLs of active feeds
+// referenced by FeedIDs. Duplicates (the same URL pasted and referenced) are
+// removed so a feed is only fetched once.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - db: Database connection
+//   - config: Configuration whose feeds to resolve
+//
+// Returns:
+//   - []string: Feed URLs to fetch
+//   - error: Database error while loading referenced feeds
+func ResolveFeedURLs(ctx context.Context, db *sql.DB, config *models.DossierConfig) ([]string, error) {
+	urls := make([]string, 0, len(config.FeedURLs)+len(config.FeedIDs))
+	seen := make(map[string]bool)
+	for _, url := range config.FeedURLs {
+		if !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+
+	if len(config.FeedIDs) == 0 {
+		return urls, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT url FROM feeds WHERE id = ANY($1) AND active = true ORDER BY id
+	`, pq.Array(config.FeedIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feeds for config %d: %w", config.ID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, fmt.Errorf("failed to scan feed url: %w", err)
+		}
+		if !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls, rows.Err()
+}
+
+// FeedGroup is the set of a configuration's feeds that share a category.
+type FeedGroup struct {
+	Category string   // Feed category ("" for feeds without one)
+	URLs     []string // Feed URLs in the group, in ResolveFeedURLs order
+}
+
+// Label returns the group's display name: its category, or "Other" for
+// uncategorized feeds.
+func (g FeedGroup) Label() string {
+	if g.Category == "" {
+		return "Other"
+	}
+	return g.Category
+}
+
+// ResolveFeedGroups returns a configuration's feed URLs grouped by the
+// category of the shared feed they belong to.
+//
+// Categories come from the feeds table, so a raw URL in FeedURLs is
+// categorized when a shared feed has the same URL. Feeds without a category
+// are grouped together. Groups are ordered by their first feed, with the
+// uncategorized group last.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - db: Database connection
+//   - config: Configuration whose feeds to group
+//
+// Returns:
+//   - []FeedGroup: Feed groups (empty if the configuration has no feeds)
+//   - error: Database error while loading feeds or categories
+func ResolveFeedGroups(ctx context.Context, db *sql.DB, config *models.DossierConfig) ([]FeedGroup, error) {
+	urls, err := ResolveFeedURLs(ctx, db, config)
+	if err != nil || len(urls) == 0 {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT url, COALESCE(category, '') FROM feeds WHERE url = ANY($1)
+	`, pq.Array(urls))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feed categories for config %d: %w", config.ID, err)
+	}
+	defer rows.Close()
+
+	categories := make(map[string]string)
+	for rows.Next() {
+		var url, category string
+		if err := rows.Scan(&url, &category); err != nil {
+			return nil, fmt.Errorf("failed to scan feed category: %w", err)
+		}
+		categories[url] = strings.TrimSpace(category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var groups []FeedGroup
+	var uncategorized FeedGroup
+	index := make(map[string]int)
+	for _, url := range urls {
+		category := categories[url]
+		if category == "" {
+			uncategorized.URLs = append(uncategorized.URLs, url)
+			continue
+		}
+		i, ok := index[category]
+		if !ok {
+			i = len(groups)
+			index[category] = i
+			groups = append(groups, FeedGroup{Category: category})
+		}
+		groups[i].URLs = append(groups[i].URLs, url)
+	}
+	if len(uncategorized.URLs) > 0 {
+		groups = append(groups, uncategorized)
+	}
+	return groups, nil
+}
+
+// ResolveFeedPriorities returns the priority of each of urls that belongs to
+// a shared feed, for the "priority" article distribution. As with
+// categories, a raw URL in FeedURLs takes the priority of a shared feed with
+// the same URL.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - db: Database connection
+//   - urls: Resolved feed URLs (see ResolveFeedURLs)
+//
+// Returns:
+//   - map[string]int: Priority by URL; URLs without a shared feed are absent
+//   - error: Database error
+func ResolveFeedPriorities(ctx context.Context, db *sql.DB, urls []string) (map[string]int, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT url, priority FROM feeds WHERE url = ANY($1)
+	`, pq.Array(urls))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feed priorities: %w", err)
+	}
+	defer rows.Close()
+
+	priorities := make(map[string]int)
+	for rows.Next() {
+		var url string
+		var priority int
+		if err := rows.Scan(&url, &priority); err != nil {
+			return nil, fmt.Errorf("failed to scan feed priority: %w", err)
+		}
+		priorities[url] = priority
+	}
+	return priorities, rows.Err()
+}
+
 // ============================================================================
 // SCHEMA MIGRATION
 // ============================================================================
@@ -79,6 +373,9 @@ func NewDB() (*sql.DB, error) {
 // Migration Strategy:
 //   - Idempotent: Safe to run multiple times
 //   - Uses CREATE TABLE IF NOT EXISTS for incremental migrations
+//   - Uses ALTER TABLE ... ADD COLUMN IF NOT EXISTS for new columns
+//   - Preserves dossier_deliveries and articles, so delivery history and the
+//     pre-fetched article pool survive restarts
 //   - Drops legacy tables from previous schema versions
 //   - Inserts default data (tones) only if not already present
 //
@@ -124,16 +421,14 @@ func Migrate(db *sql.DB) error {
 	-- ========================================================================
 	-- CLEANUP: Drop legacy tables from previous schema versions
 	-- ========================================================================
-	-- These tables are from earlier iterations and are no longer used
-	DROP TABLE IF EXISTS delivery_articles CASCADE;
+	-- These tables are from earlier iterations and are no longer used.
+	-- articles and delivery_articles are current tables (the pre-fetch pool
+	-- lives in articles) and are kept like every other current table.
 	DROP TABLE IF EXISTS dossier_articles CASCADE;
-	DROP TABLE IF EXISTS dossier_deliveries CASCADE;
 	DROP TABLE IF EXISTS digest_articles CASCADE;
 	DROP TABLE IF EXISTS digest_deliveries CASCADE;
 	DROP TABLE IF EXISTS digest_configs CASCADE;
 	DROP TABLE IF EXISTS digests CASCADE;
-	DROP TABLE IF EXISTS articles CASCADE;
-	DROP TABLE IF EXISTS feeds CASCADE;
 	DROP TABLE IF EXISTS users CASCADE;
 
 	-- ========================================================================
@@ -166,6 +461,67 @@ func Migrate(db *sql.DB) error {
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);
 
+	-- Incremental columns for dossier_configs (added after initial release)
+	--   - rollup_source_id: When set, this config is a rollup that summarizes
+	--     the referenced config's recent deliveries instead of fetching feeds
+	--   - interests: Reader interests used to rank articles by relevance
+	--   - enforce_language: Check output language and translate mismatches
+	--   - include_executive_summary: Generate and include the executive summary section
+	--   - include_conclusion: Generate and include the conclusion section
+	--   - skip_weekends: Skip scheduled deliveries on Saturdays and Sundays
+	--   - skip_dates: Dates (YYYY-MM-DD) on which scheduled delivery is skipped
+	--   - feed_ids: IDs of shared feeds (feeds table) used alongside feed_urls
+	--   - min_articles: Fewest fetched articles worth sending (smaller runs are skipped)
+	--   - delivery_weekdays: Weekdays (0=Sunday..6=Saturday) a weekly config delivers on; empty means Mondays
+	--   - max_per_source: Most articles taken from any one domain (0 = no limit)
+	--   - cta_label: Article "read more" link text (empty = built-in default)
+	--   - footer_text: Email footer line (empty = built-in default)
+	--   - articles_only_fallback: Send an articles-only email when summary generation fails
+	--   - order_by_importance: Order articles by AI-assigned importance (top story first)
+	--   - preserve_titles: Keep article titles untranslated in generated summaries
+	--   - attach_pdf: Attach a PDF copy of the dossier to each email
+	--   - sandbox_sends: Remaining accelerated sandbox deliveries (0 = normal schedule)
+	--   - pipeline: 'robust' (scrape, per-article summaries) or 'simple' (one summary call from feed text)
+	--   - temperature: Sampling temperature override (NULL = server default)
+	--   - use_feed_content: Use feed-provided article text instead of scraping
+	--   - split_by_category: Send one dossier per feed category
+	--   - recipient_name: Name greeted at the top of each email ('' = no greeting)
+	--   - since_last_delivery: Only include articles published after the last sent dossier
+	--   - sponsored_filter: 'drop', 'demote', or 'off' for sponsored/ad-labeled feed items
+	--   - sponsored_patterns: Patterns marking sponsored items ('{}' = server defaults)
+	--   - distribution: 'even', 'priority', or 'newest' division of article_count between feeds
+	--   - tone_intensity: 'subtle', 'normal', or 'strong' application of the tone
+	--   - candidate_pool_size: Articles ranked by selection, beyond article_count listed as "also noted" (0 = article_count)
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS rollup_source_id INTEGER REFERENCES dossier_configs(id) ON DELETE SET NULL;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS interests TEXT DEFAULT '';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS enforce_language BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS include_executive_summary BOOLEAN DEFAULT true;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS include_conclusion BOOLEAN DEFAULT true;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS skip_weekends BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS skip_dates TEXT[] DEFAULT '{}';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS feed_ids INTEGER[] DEFAULT '{}';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS min_articles INTEGER DEFAULT 1 CHECK (min_articles >= 1);
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS delivery_weekdays INTEGER[] DEFAULT '{}';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS max_per_source INTEGER DEFAULT 0 CHECK (max_per_source >= 0);
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS cta_label TEXT DEFAULT '';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS footer_text TEXT DEFAULT '';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS articles_only_fallback BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS order_by_importance BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS preserve_titles BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS attach_pdf BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS sandbox_sends INTEGER DEFAULT 0 CHECK (sandbox_sends >= 0);
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS pipeline VARCHAR(20) DEFAULT 'robust';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS temperature DOUBLE PRECISION CHECK (temperature >= 0 AND temperature <= 2);
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS use_feed_content BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS split_by_category BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS recipient_name VARCHAR(100) DEFAULT '';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS since_last_delivery BOOLEAN DEFAULT false;
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS sponsored_filter VARCHAR(20) DEFAULT 'drop';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS sponsored_patterns TEXT[] DEFAULT '{}';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS distribution VARCHAR(20) DEFAULT 'even';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS tone_intensity VARCHAR(20) DEFAULT 'normal';
+	ALTER TABLE dossier_configs ADD COLUMN IF NOT EXISTS candidate_pool_size INTEGER DEFAULT 0 CHECK (candidate_pool_size BETWEEN 0 AND 100);
+
 	-- ========================================================================
 	-- TABLE: feeds
 	-- ========================================================================
@@ -174,8 +530,12 @@ func Migrate(db *sql.DB) error {
 	--
 	-- Key Fields:
 	--   - url: Unique feed URL
+	--   - category: Free-form grouping label shared by every config using the feed
+	--   - priority: Weight (1-10) for configs using the 'priority' distribution
 	--   - active: Can temporarily disable problematic feeds
 	--   - last_fetched: Track fetch schedule and detect stale feeds
+	--
+	-- Configs reference feeds through dossier_configs.feed_ids
 	-- ========================================================================
 	CREATE TABLE IF NOT EXISTS feeds (
 		id SERIAL PRIMARY KEY,
@@ -188,6 +548,9 @@ func Migrate(db *sql.DB) error {
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);
 
+	ALTER TABLE feeds ADD COLUMN IF NOT EXISTS category VARCHAR(100) DEFAULT '';
+	ALTER TABLE feeds ADD COLUMN IF NOT EXISTS priority INTEGER NOT NULL DEFAULT 1 CHECK (priority BETWEEN 1 AND 10);
+
 	-- ========================================================================
 	-- TABLE: articles
 	-- ========================================================================
@@ -199,6 +562,11 @@ func Migrate(db *sql.DB) error {
 	--   - published_at: Original publication date (for sorting/filtering)
 	--   - content: Full article content if available
 	--   - description: Article summary/excerpt
+	--   - media_url/media_type: Enclosure for podcast/video items
+	--   - feed_url: Feed an article was pre-fetched from (set by the scheduler's
+	--     pre-fetcher; generations read the pool by feed URL because configs
+	--     may list raw URLs that have no feeds row)
+	--   - description_html: Sanitized description markup for email display
 	-- ========================================================================
 	CREATE TABLE IF NOT EXISTS articles (
 		id SERIAL PRIMARY KEY,
@@ -208,10 +576,16 @@ func Migrate(db *sql.DB) error {
 		description TEXT,
 		content TEXT,
 		author VARCHAR(255),
+		media_url TEXT,
+		media_type VARCHAR(100),
 		published_at TIMESTAMP NOT NULL,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);
 
+	ALTER TABLE articles ADD COLUMN IF NOT EXISTS feed_url TEXT;
+	ALTER TABLE articles ADD COLUMN IF NOT EXISTS description_html TEXT;
+	ALTER TABLE articles ADD COLUMN IF NOT EXISTS categories TEXT[] DEFAULT '{}';
+
 	-- ========================================================================
 	-- TABLE: dossier_deliveries
 	-- ========================================================================
@@ -223,6 +597,19 @@ func Migrate(db *sql.DB) error {
 	--   - summary: Generated AI summary (stored for archive)
 	--   - email_sent: Delivery status tracking
 	--   - delivery_date: When the dossier was sent
+	--   - skip_reason: Set (with email_sent false) when a scheduled run was
+	--     skipped, e.g. fewer articles than the config's min_articles
+	--   - search_vector: Full-text index of the summary with HTML tags stripped
+	--     (maintained by PostgreSQL, used by searchDeliveries)
+	--   - idempotency_key: Scheduled period a delivery belongs to (NULL for
+	--     manual runs); unique, so each period is claimed and sent only once
+	--   - llm_calls, llm_tokens, pages_scraped, duration_ms: Work the
+	--     generation did (0 for rows recorded before usage was tracked)
+	--   - error_message: Why generation or sending failed (NULL unless the
+	--     run failed; failed rows never count as the period's delivery)
+	--   - articles: The dossier's articles as JSON, stored with the summary
+	--     when generation succeeded but the email failed, so the delivery
+	--     can be resent without regenerating (NULL otherwise)
 	-- ========================================================================
 	CREATE TABLE IF NOT EXISTS dossier_deliveries (
 		id SERIAL PRIMARY KEY,
@@ -234,6 +621,17 @@ func Migrate(db *sql.DB) error {
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);
 
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS skip_reason TEXT DEFAULT '';
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('english', regexp_replace(summary, '<[^>]+>', ' ', 'g'))) STORED;
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS idempotency_key TEXT;
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS llm_calls INTEGER DEFAULT 0;
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS llm_tokens INTEGER DEFAULT 0;
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS pages_scraped INTEGER DEFAULT 0;
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS duration_ms BIGINT DEFAULT 0;
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS error_message TEXT;
+	ALTER TABLE dossier_deliveries ADD COLUMN IF NOT EXISTS articles JSONB;
+
 	-- ========================================================================
 	-- TABLE: delivery_articles
 	-- ========================================================================
@@ -284,6 +682,9 @@ func Migrate(db *sql.DB) error {
 	-- Article sorting and time-range filtering
 	CREATE INDEX IF NOT EXISTS idx_articles_published_at ON articles(published_at);
 	
+	-- Pre-fetched article pool lookups by feed
+	CREATE INDEX IF NOT EXISTS idx_articles_feed_url ON articles(feed_url, published_at);
+	
 	-- Active dossier filtering for scheduler
 	CREATE INDEX IF NOT EXISTS idx_dossier_configs_active ON dossier_configs(active);
 	
@@ -292,6 +693,12 @@ func Migrate(db *sql.DB) error {
 	
 	-- Delivery chronological queries and archive pagination
 	CREATE INDEX IF NOT EXISTS idx_dossier_deliveries_date ON dossier_deliveries(delivery_date);
+
+	-- Full-text search over delivery content
+	CREATE INDEX IF NOT EXISTS idx_dossier_deliveries_search ON dossier_deliveries USING GIN(search_vector);
+
+	-- One delivery per scheduled period (NULL keys, i.e. manual runs, never conflict)
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_dossier_deliveries_idempotency_key ON dossier_deliveries(idempotency_key);
 	
 	-- Tone lookup by name (most common query pattern)
 	CREATE INDEX IF NOT EXISTS idx_tones_name ON tones(name);
@@ -10,20 +10,32 @@
 //   - Support for both STARTTLS and direct TLS
 //   - Environment-based configuration
 //   - Connection testing capabilities
+//   - Optional connection reuse for batch sends (see Batch)
+//   - Optional PDF copy of the dossier as an attachment (see pdf.go)
+//   - Retries with backoff for transient SMTP failures (see retry.go)
+//   - Signed unsubscribe and manage links usable without logging in (see links.go)
 package email
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
+	"net/mail"
 	"net/smtp"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/geraldfingburke/dossier/server/internal/models"
+	"github.com/geraldfingburke/dossier/server/internal/textutil"
 )
 
 // ============================================================================
@@ -37,8 +49,52 @@ type Config struct {
 	SMTPPort  string // SMTP server port ("587" for STARTTLS, "465" for direct TLS)
 	Username  string // SMTP authentication username (usually email address)
 	Password  string // SMTP authentication password or app-specific password
-	FromEmail string // Sender email address
+	FromEmail string // Sender email address (the From header)
 	FromName  string // Display name for sender
+
+	// EnvelopeFrom is the SMTP MAIL FROM address (envelope sender / return
+	// path). Strict providers only accept senders the authenticated account
+	// owns, so it defaults to Username when that is an email address.
+	EnvelopeFrom string
+
+	// DescriptionLength caps article descriptions in the email (in characters).
+	// 0 omits descriptions entirely; a negative value disables truncation.
+	DescriptionLength int
+
+	// MaxEmailBytes caps the size of the complete MIME message. Oversized emails
+	// are degraded (images stripped, descriptions shortened) before sending.
+	// 0 disables the check.
+	MaxEmailBytes int
+
+	// BatchSend lets callers that deliver several dossiers at once (the
+	// scheduler's per-minute tick) share one SMTP connection via Batch.
+	BatchSend bool
+
+	// PDFRenderCommand converts dossier HTML (stdin) to PDF (stdout) for
+	// configs with attach_pdf. Empty disables PDF attachments.
+	PDFRenderCommand []string
+
+	// MaxPDFBytes caps the size of a rendered PDF. Larger PDFs are not
+	// attached. 0 disables the check.
+	MaxPDFBytes int
+
+	// ArchiveBCC lists addresses that silently receive a copy of every
+	// dossier. They are added to the SMTP RCPT TO list only, never to a header.
+	ArchiveBCC []string
+
+	// SendAttempts is the number of tries per message when sending fails
+	// transiently (SMTP 4xx replies, dropped connections); 1 disables retries.
+	// SendRetryDelay is the wait before the first retry, doubling after that.
+	SendAttempts   int
+	SendRetryDelay time.Duration
+
+	// PublicURL is the server's externally reachable base URL, used for the
+	// signed unsubscribe and manage links in each email. LinkSecret signs
+	// those links and LinkTTL is how long they stay valid. Links are only
+	// added when both PublicURL and LinkSecret are set.
+	PublicURL  string
+	LinkSecret []byte
+	LinkTTL    time.Duration
 }
 
 // Service handles all email operations including template rendering and SMTP delivery.
@@ -49,11 +105,21 @@ type Service struct {
 // DossierEmail represents a complete email ready for delivery.
 // Contains both HTML and plain text versions for maximum compatibility.
 type DossierEmail struct {
-	To          string      // Recipient email address
-	Subject     string      // Email subject line
-	HTMLBody    string      // HTML version of email body
-	TextBody    string      // Plain text version of email body
-	DossierData DossierData // Structured data for template rendering
+	To          string       // Recipient email address
+	Bcc         []string     // Envelope-only recipients (never written to a header)
+	Subject     string       // Email subject line
+	HTMLBody    string       // HTML version of email body
+	TextBody    string       // Plain text version of email body
+	DossierData DossierData  // Structured data for template rendering
+	Attachments []Attachment // Files attached alongside the bodies (e.g. the PDF copy)
+	Unsubscribe string       // One-click unsubscribe URL for the List-Unsubscribe header (empty for none)
+}
+
+// Attachment is a file sent with an email as a multipart/mixed part.
+type Attachment struct {
+	Filename    string // Name shown to the recipient
+	ContentType string // MIME type (e.g., "application/pdf")
+	Data        []byte // Raw file content (base64-encoded when sent)
 }
 
 // DossierData contains structured information for rendering dossier email templates.
@@ -67,17 +133,65 @@ type DossierData struct {
 	Tone         string        // AI tone used for summary
 	Language     string        // Language of the summary
 	Instructions string        // Special instructions applied (if any)
+	CTALabel     string        // Per-article link text in the plain-text body (empty for "Read more")
+	FooterText   string        // Custom footer line (empty for the default Dossier footer)
+	Greeting     string        // Opening line such as "Good morning, Alex" (empty for no greeting)
+
+	// Signed links to unsubscribe or manage delivery without logging in
+	// (empty when PUBLIC_URL or LINK_SIGNING_SECRET is not set)
+	UnsubscribeURL string
+	ManageURL      string
 }
 
 // ArticleData represents a single article in the email template.
 type ArticleData struct {
-	Title       string    // Article headline
-	Description string    // Article summary/excerpt
-	URL         string    // Full article URL
-	Source      string    // Domain name of source (extracted from URL)
-	PublishedAt time.Time // Original publication date
+	Title           string        // Article headline
+	Description     string        // Article summary/excerpt (plain text, possibly truncated)
+	DescriptionHTML template.HTML // Sanitized HTML excerpt, shown instead of Description when it fits untruncated
+	URL             string        // Full article URL
+	Source          string        // Domain name of source (extracted from URL)
+	MediaURL        string        // Podcast/video enclosure URL (empty if none)
+	MediaLabel      string        // Link text for the enclosure (e.g., "Listen to episode")
+	PublishedAt     time.Time     // Original publication date
 }
 
+const (
+	// defaultDescriptionLength is the article description cap used when
+	// EMAIL_DESCRIPTION_LENGTH is not set
+	defaultDescriptionLength = 300
+
+	// defaultMaxEmailBytes keeps messages safely below the ~25MB limit of
+	// common providers (Gmail, Outlook) when EMAIL_MAX_BYTES is not set
+	defaultMaxEmailBytes = 20 * 1024 * 1024
+
+	// reducedDescriptionLength is the description cap applied when an email
+	// is over the size limit
+	reducedDescriptionLength = 100
+
+	// defaultMaxPDFBytes caps attached PDFs when PDF_MAX_BYTES is not set
+	defaultMaxPDFBytes = 5 * 1024 * 1024
+)
+
+// ArticlesOnlySummary stands in for the AI summary when generation failed and
+// the configuration opted to receive the article list anyway.
+const ArticlesOnlySummary = "<p><em>The AI summary could not be generated for this edition. " +
+	"The articles below were collected as usual.</em></p>"
+
+// SandboxSubjectPrefix marks dossiers from configurations in sandbox mode,
+// which deliver on an accelerated test schedule.
+const SandboxSubjectPrefix = "[SANDBOX] "
+
+// ErrEmailFailed wraps every error from SendDossier (on the Service or a
+// Batch): rendering, size limits, and SMTP delivery alike.
+var ErrEmailFailed = errors.New("failed to send email")
+
+// ErrEmailTooLarge is returned when a dossier email exceeds EMAIL_MAX_BYTES
+// even after images and descriptions have been removed.
+var ErrEmailTooLarge = errors.New("email exceeds maximum size")
+
+// imgTagPattern matches embedded <img> tags in AI-assembled summary HTML.
+var imgTagPattern = regexp.MustCompile(`(?i)<img[^>]*>`)
+
 // ============================================================================
 // SERVICE INITIALIZATION
 // ============================================================================
@@ -92,6 +206,30 @@ type ArticleData struct {
 //   - SMTP_PASSWORD: Authentication password (default: "")
 //   - SMTP_FROM_EMAIL: Sender email address (default: "dossier@localhost")
 //   - SMTP_FROM_NAME: Sender display name (default: "Dossier")
+//   - SMTP_ENVELOPE_FROM: SMTP MAIL FROM address (default: SMTP_USERNAME when
+//     it is an email address, otherwise SMTP_FROM_EMAIL)
+//   - EMAIL_DESCRIPTION_LENGTH: Max article description characters; 0 omits,
+//     negative disables truncation (default: 300)
+//   - EMAIL_MAX_BYTES: Max MIME message size in bytes; 0 disables (default: 20MB)
+//   - SMTP_BATCH_SEND: "true" to reuse one SMTP connection for dossiers the
+//     scheduler delivers in the same minute (default: disabled)
+//   - PDF_RENDER_COMMAND: HTML-to-PDF command for attach_pdf configs, reading
+//     HTML on stdin and writing PDF to stdout (e.g. "wkhtmltopdf --quiet - -";
+//     default: unset, PDFs disabled)
+//   - PDF_MAX_BYTES: Largest PDF that is attached; 0 disables (default: 5MB)
+//   - ARCHIVE_BCC: Comma-separated addresses blind-copied on every dossier,
+//     including test emails (default: unset)
+//   - SMTP_SEND_ATTEMPTS: Tries per message when the server answers with a
+//     temporary 4xx reply or the connection fails (default: 3; 1 disables
+//     retries). 5xx rejects are never retried
+//   - SMTP_RETRY_DELAY: Wait before the first retry, doubling after each, as
+//     a Go duration (default: "30s")
+//   - PUBLIC_URL: Externally reachable base URL of this server, for the
+//     unsubscribe and manage links in emails (default: unset, no links)
+//   - LINK_SIGNING_SECRET: Secret that signs those links; changing it
+//     invalidates links already sent (default: unset, no links)
+//   - LINK_TTL: How long a signed link stays valid, as a Go duration
+//     (default: "720h", 30 days)
 //
 // Port Selection Guide:
 //   - 587: Use STARTTLS (upgrade plain connection to TLS)
@@ -113,11 +251,146 @@ func NewService() *Service {
 		Password:  getEnvOrDefault("SMTP_PASSWORD", ""),
 		FromEmail: getEnvOrDefault("SMTP_FROM_EMAIL", "dossier@localhost"),
 		FromName:  getEnvOrDefault("SMTP_FROM_NAME", "Dossier"),
+
+		DescriptionLength: getEnvIntOrDefault("EMAIL_DESCRIPTION_LENGTH", defaultDescriptionLength),
+		MaxEmailBytes:     getEnvIntOrDefault("EMAIL_MAX_BYTES", defaultMaxEmailBytes),
+		BatchSend:         os.Getenv("SMTP_BATCH_SEND") == "true",
+
+		PDFRenderCommand: strings.Fields(os.Getenv("PDF_RENDER_COMMAND")),
+		MaxPDFBytes:      getEnvIntOrDefault("PDF_MAX_BYTES", defaultMaxPDFBytes),
+
+		ArchiveBCC: parseArchiveBCC(os.Getenv("ARCHIVE_BCC")),
+
+		SendAttempts:   getEnvIntOrDefault("SMTP_SEND_ATTEMPTS", defaultSendAttempts),
+		SendRetryDelay: getEnvDurationOrDefault("SMTP_RETRY_DELAY", defaultSendRetryDelay),
+
+		PublicURL:  strings.TrimRight(strings.TrimSpace(os.Getenv("PUBLIC_URL")), "/"),
+		LinkSecret: []byte(os.Getenv("LINK_SIGNING_SECRET")),
+		LinkTTL:    getEnvDurationOrDefault("LINK_TTL", defaultLinkTTL),
+	}
+	if config.SendAttempts < 1 {
+		log.Printf("Invalid SMTP_SEND_ATTEMPTS %d, using default %d", config.SendAttempts, defaultSendAttempts)
+		config.SendAttempts = defaultSendAttempts
+	}
+
+	if config.LinkTTL == 0 {
+		log.Printf("Invalid LINK_TTL 0, using default %s", defaultLinkTTL)
+		config.LinkTTL = defaultLinkTTL
+	}
+	if secret := len(config.LinkSecret); secret > 0 && secret < minLinkSecretLength {
+		log.Printf("Warning: LINK_SIGNING_SECRET is shorter than %d characters; use a long random value", minLinkSecretLength)
+	}
+	if (config.PublicURL == "") != (len(config.LinkSecret) == 0) {
+		log.Println("Warning: unsubscribe links need both PUBLIC_URL and LINK_SIGNING_SECRET; emails are sent without them")
+	}
+
+	config.EnvelopeFrom = defaultEnvelopeFrom(config)
+
+	for _, warning := range senderWarnings(config) {
+		log.Printf("Warning: %s", warning)
 	}
 
 	return &Service{config: config}
 }
 
+// Settings reports the service's effective configuration for the
+// serverConfig query. The SMTP password and link signing secret are only
+// reported as set or not set.
+//
+// Returns:
+//   - []models.Setting: Effective values
+func (s *Service) Settings() []models.Setting {
+	return []models.Setting{
+		{Name: "SMTP_HOST", Value: s.config.SMTPHost},
+		{Name: "SMTP_PORT", Value: s.config.SMTPPort},
+		{Name: "SMTP_USERNAME", Value: s.config.Username},
+		{Name: "SMTP_PASSWORD", Secret: true},
+		{Name: "SMTP_FROM_EMAIL", Value: s.config.FromEmail},
+		{Name: "SMTP_FROM_NAME", Value: s.config.FromName},
+		{Name: "SMTP_ENVELOPE_FROM", Value: s.config.EnvelopeFrom},
+		{Name: "SMTP_SEND_ATTEMPTS", Value: strconv.Itoa(s.config.SendAttempts)},
+		{Name: "PUBLIC_URL", Value: s.config.PublicURL},
+		{Name: "LINK_SIGNING_SECRET", Secret: true},
+		{Name: "PDF_RENDER_COMMAND", Value: strings.Join(s.config.PDFRenderCommand, " ")},
+	}
+}
+
+// defaultEnvelopeFrom resolves the envelope sender: SMTP_ENVELOPE_FROM if set,
+// else the username when it is an email address, else the From address.
+func defaultEnvelopeFrom(config Config) string {
+	if explicit := strings.TrimSpace(os.Getenv("SMTP_ENVELOPE_FROM")); explicit != "" {
+		return explicit
+	}
+	if _, err := mail.ParseAddress(config.Username); err == nil {
+		return config.Username
+	}
+	return config.FromEmail
+}
+
+// senderWarnings checks the sender addresses for combinations that strict
+// providers commonly reject. Only what can be judged locally is checked;
+// whether the account may send as a given address is up to the provider.
+//
+// Parameters:
+//   - config: Email configuration with EnvelopeFrom resolved
+//
+// Returns:
+//   - []string: Human-readable problems (empty when consistent)
+func senderWarnings(config Config) []string {
+	var warnings []string
+	if _, err := mail.ParseAddress(config.EnvelopeFrom); err != nil {
+		warnings = append(warnings, fmt.Sprintf("SMTP envelope sender %q is not a valid email address", config.EnvelopeFrom))
+		return warnings
+	}
+
+	// An authenticated account that is itself an address usually may only
+	// use that address (or its domain) as the envelope sender
+	if _, err := mail.ParseAddress(config.Username); err == nil &&
+		!strings.EqualFold(config.EnvelopeFrom, config.Username) &&
+		!strings.EqualFold(addressDomain(config.EnvelopeFrom), addressDomain(config.Username)) {
+		warnings = append(warnings, fmt.Sprintf(
+			"SMTP envelope sender %s is not on the authenticated account's domain (%s); strict providers may reject it with \"sender address rejected\"",
+			config.EnvelopeFrom, config.Username))
+	}
+
+	// SPF checks the envelope domain while DMARC requires it to align with
+	// the From header domain
+	if !strings.EqualFold(addressDomain(config.EnvelopeFrom), addressDomain(config.FromEmail)) {
+		warnings = append(warnings, fmt.Sprintf(
+			"SMTP envelope sender %s and From address %s are on different domains; receivers enforcing DMARC may reject or junk messages",
+			config.EnvelopeFrom, config.FromEmail))
+	}
+	return warnings
+}
+
+// addressDomain returns the lower-cased domain part of an email address.
+func addressDomain(address string) string {
+	if at := strings.LastIndex(address, "@"); at >= 0 {
+		return strings.ToLower(address[at+1:])
+	}
+	return ""
+}
+
+// parseArchiveBCC parses the comma-separated ARCHIVE_BCC list, keeping only
+// the bare address of each entry. Invalid entries are logged and skipped so a
+// typo cannot break delivery to the real recipient.
+func parseArchiveBCC(value string) []string {
+	var addresses []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		address, err := mail.ParseAddress(entry)
+		if err != nil {
+			log.Printf("Warning: ignoring invalid ARCHIVE_BCC address %q: %v", entry, err)
+			continue
+		}
+		addresses = append(addresses, address.Address)
+	}
+	return addresses
+}
+
 // getEnvOrDefault retrieves an environment variable value or returns a default.
 //
 // Parameters:
@@ -133,6 +406,28 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvIntOrDefault retrieves an integer environment variable or returns a default.
+// Unparseable values are logged and replaced by the default.
+//
+// Parameters:
+//   - key: Environment variable name
+//   - defaultValue: Fallback value if variable is not set or invalid
+//
+// Returns:
+//   - int: Parsed environment variable value or default
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid %s %q, using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
 // ============================================================================
 // PUBLIC API - EMAIL DELIVERY
 // ============================================================================
@@ -142,7 +437,8 @@ func getEnvOrDefault(key, defaultValue string) string {
 //
 // Process Flow:
 //  1. Transform articles into email-friendly data structures
-//  2. Extract domain names from URLs for source attribution
+//  2. Extract domain names from URLs for source attribution and truncate
+//     descriptions to EMAIL_DESCRIPTION_LENGTH
 //  3. Compile dossier data with metadata
 //  4. Generate HTML and plain text versions via templates
 //  5. Build MIME multi-part message
@@ -156,32 +452,110 @@ func getEnvOrDefault(key, defaultValue string) string {
 //   - Metadata (generation time, article count, tone, etc.)
 //
 // Parameters:
+//   - ctx: Context that cancels pending send retries
 //   - config: Dossier configuration (recipient, title, preferences)
 //   - summary: AI-generated HTML summary of articles
 //   - articles: List of articles to include in email
 //
 // Returns:
-//   - error: Template rendering or SMTP delivery failure
+//   - error: Template rendering or SMTP delivery failure, wrapping ErrEmailFailed
 //
 // Example:
 //
-//	err := emailService.SendDossier(dossierConfig, aiSummary, articles)
+//	err := emailService.SendDossier(ctx, dossierConfig, aiSummary, articles)
 //	if err != nil {
 //	    log.Printf("Failed to send dossier: %v", err)
 //	}
-func (s *Service) SendDossier(config *models.DossierConfig, summary string, articles []models.Article) error {
+func (s *Service) SendDossier(ctx context.Context, config *models.DossierConfig, summary string, articles []models.Article) error {
 	log.Printf("Preparing to send dossier email: %s to %s", config.Title, config.Email)
 
+	email, err := s.buildDossierEmail(config, summary, articles)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEmailFailed, err)
+	}
+
+	// Send via SMTP
+	return s.sendEmail(ctx, email)
+}
+
+// RenderDossierHTML renders a dossier's HTML body exactly as SendDossier
+// would send it, without sending anything. Used by the /preview route.
+//
+// Parameters:
+//   - config: Dossier configuration (title and presentation settings)
+//   - summary: AI-generated summary HTML
+//   - articles: Articles included in the dossier
+//
+// Returns:
+//   - string: HTML email body
+//   - error: Template rendering failure
+func (s *Service) RenderDossierHTML(config *models.DossierConfig, summary string, articles []models.Article) (string, error) {
+	email, err := s.buildDossierEmail(config, summary, articles)
+	if err != nil {
+		return "", err
+	}
+	return email.HTMLBody, nil
+}
+
+// greeting returns the opening line for a dossier addressed to name, such as
+// "Good morning, Alex". The time of day comes from now in the configuration's
+// timezone: morning before noon, afternoon until 6 PM, evening after that.
+//
+// Parameters:
+//   - name: Recipient name (empty for no greeting)
+//   - timezone: IANA timezone of the configuration (server local if invalid)
+//   - now: Time the dossier is generated
+//
+// Returns:
+//   - string: Greeting line, or "" when name is empty
+func greeting(name, timezone string, now time.Time) string {
+	if name == "" {
+		return ""
+	}
+	if location, err := time.LoadLocation(timezone); err == nil {
+		now = now.In(location)
+	} else {
+		now = now.In(time.Local)
+	}
+
+	switch hour := now.Hour(); {
+	case hour < 12:
+		return "Good morning, " + name
+	case hour < 18:
+		return "Good afternoon, " + name
+	default:
+		return "Good evening, " + name
+	}
+}
+
+// buildDossierEmail renders the HTML and text bodies for a dossier.
+//
+// Parameters:
+//   - config: Dossier configuration (recipient, title, presentation settings)
+//   - summary: AI-generated summary HTML
+//   - articles: Articles included in the dossier
+//
+// Returns:
+//   - DossierEmail: Email ready for MIME assembly
+//   - error: Template rendering failure
+func (s *Service) buildDossierEmail(config *models.DossierConfig, summary string, articles []models.Article) (DossierEmail, error) {
 	// Transform articles into email data structures
 	articleData := make([]ArticleData, len(articles))
 	for i, article := range articles {
 		articleData[i] = ArticleData{
 			Title:       article.Title,
-			Description: article.Description,
+			Description: truncateDescription(article.Description, s.config.DescriptionLength),
 			URL:         article.Link,
-			Source:      extractDomain(article.Link),
+			Source:      textutil.ExtractDomain(article.Link),
+			MediaURL:    article.MediaURL,
+			MediaLabel:  textutil.MediaLinkLabel(article.MediaType),
 			PublishedAt: article.PublishedAt,
 		}
+		// Formatted HTML can't be cut safely, so it is only used when the
+		// whole description fits; rss.NormalizeItemText already sanitized it
+		if article.DescriptionHTML != "" && articleData[i].Description == strings.TrimSpace(article.Description) {
+			articleData[i].DescriptionHTML = template.HTML(article.DescriptionHTML)
+		}
 	}
 
 	// Compile dossier data for template rendering
@@ -194,25 +568,150 @@ func (s *Service) SendDossier(config *models.DossierConfig, summary string, arti
 		Tone:         config.Tone,
 		Language:     config.Language,
 		Instructions: config.SpecialInstructions,
+		CTALabel:     config.CTALabel,
+		FooterText:   config.FooterText,
 	}
+	dossierData.Greeting = greeting(config.RecipientName, config.Timezone, dossierData.GeneratedAt)
+	dossierData.UnsubscribeURL = s.linkURL(config.ID, LinkUnsubscribe, dossierData.GeneratedAt)
+	dossierData.ManageURL = s.linkURL(config.ID, LinkManage, dossierData.GeneratedAt)
 
 	// Generate HTML and text email content
 	htmlBody, textBody, err := s.generateEmailContent(dossierData)
 	if err != nil {
-		return fmt.Errorf("failed to generate email content: %w", err)
+		return DossierEmail{}, fmt.Errorf("failed to generate email content: %w", err)
+	}
+
+	subject := fmt.Sprintf("Dossier - %s", config.Title)
+	if config.SandboxSends > 0 {
+		subject = SandboxSubjectPrefix + subject
 	}
 
-	// Create email structure
 	email := DossierEmail{
 		To:          config.Email,
-		Subject:     fmt.Sprintf("Dossier - %s", config.Title),
+		Bcc:         s.config.ArchiveBCC,
+		Subject:     subject,
 		HTMLBody:    htmlBody,
 		TextBody:    textBody,
 		DossierData: dossierData,
+		Unsubscribe: dossierData.UnsubscribeURL,
 	}
 
-	// Send via SMTP
-	return s.sendEmail(email)
+	// The PDF is an extra; the dossier still goes out without it
+	if config.AttachPDF {
+		if attachment, err := s.renderPDFAttachment(config.Title, htmlBody, dossierData.GeneratedAt); err != nil {
+			log.Printf("Warning: sending %s without its PDF copy: %v", config.Title, err)
+		} else {
+			email.Attachments = append(email.Attachments, attachment)
+		}
+	}
+
+	return email, nil
+}
+
+// SendAdminNotification sends a short plain-text operational notice, such as a
+// delivery failure alert, to an administrator address.
+//
+// The message bypasses dossier templating and size limiting; the body is sent
+// as text and wrapped in a <pre> block for the HTML alternative.
+//
+// Parameters:
+//   - to: Administrator email address
+//   - subject: Subject line
+//   - body: Plain text body
+//
+// Returns:
+//   - error: SMTP connection or delivery failure
+func (s *Service) SendAdminNotification(to, subject, body string) error {
+	email := DossierEmail{
+		To:       to,
+		Subject:  subject,
+		TextBody: body,
+		HTMLBody: "<pre style='font-family: monospace; white-space: pre-wrap;'>" + template.HTMLEscapeString(body) + "</pre>",
+	}
+
+	err := s.sendSMTPWithTLS(s.config.EnvelopeFrom, []string{to}, []byte(s.buildMIMEMessage(email)))
+	if err != nil {
+		return fmt.Errorf("failed to send admin notification: %w", err)
+	}
+
+	log.Printf("Sent admin notification to %s: %s", to, subject)
+	return nil
+}
+
+// TestDossier builds the sample content used by test emails: a summary that
+// lists the configuration's settings and feeds, plus three placeholder
+// articles. Sending it with SendDossier exercises the real template and SMTP
+// path without fetching feeds or calling the AI service.
+//
+// Parameters:
+//   - config: Configuration being tested
+//   - feedURLs: Resolved feed URLs to list in the summary
+//   - now: Timestamp shown in the email and used for sample articles
+//
+// Returns:
+//   - *models.DossierConfig: Copy of config with a "- Test Email" title suffix
+//   - string: Sample summary content
+//   - []models.Article: Placeholder articles
+func TestDossier(config *models.DossierConfig, feedURLs []string, now time.Time) (*models.DossierConfig, string, []models.Article) {
+	testContent := `This is a test email from your Dossier system.
+
+**Configuration Details:**
+- Title: ` + config.Title + `
+- Frequency: ` + config.Frequency + `
+- Delivery Time: ` + config.DeliveryTime + ` (` + config.Timezone + `)
+- Article Count: ` + fmt.Sprintf("%d", config.ArticleCount) + `
+- AI Tone: ` + config.Tone + `
+- Language: ` + config.Language + `
+
+**RSS Feeds:**`
+
+	for i, feedURL := range feedURLs {
+		testContent += fmt.Sprintf("\n%d. %s", i+1, feedURL)
+	}
+
+	testContent += `
+
+**Sample Articles:** _(This is test data)_
+1. **Breaking News: Technology Advances Continue** - Lorem ipsum dolor sit amet, consectetur adipiscing elit.
+2. **Market Update: Economic Trends Show Growth** - Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.
+3. **Innovation Spotlight: New Developments** - Ut enim ad minim veniam, quis nostrud exercitation ullamco.
+
+---
+*This was a test email sent at ` + now.Format("2006-01-02 15:04:05 MST") + `*
+*Your actual dossiers will contain real articles from your configured RSS feeds.*`
+
+	// Modify config title to indicate test email
+	testConfig := *config
+	testConfig.Title = config.Title + " - Test Email"
+
+	// Create sample articles for email template rendering
+	sampleArticles := []models.Article{
+		{
+			ID:          1,
+			Title:       "Breaking News: Technology Advances Continue",
+			Link:        "https://example.com/article1",
+			Description: "Lorem ipsum dolor sit amet, consectetur adipiscing elit.",
+			Author:      "Test Author",
+			PublishedAt: now,
+		},
+		{
+			ID:          2,
+			Title:       "Market Update: Economic Trends Show Growth",
+			Link:        "https://example.com/article2",
+			Description: "Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.",
+			Author:      "Test Reporter",
+			PublishedAt: now.Add(-1 * time.Hour),
+		},
+		{
+			ID:          3,
+			Title:       "Innovation Spotlight: New Developments",
+			Link:        "https://example.com/article3",
+			Description: "Ut enim ad minim veniam, quis nostrud exercitation ullamco.",
+			Author:      "Tech Writer",
+			PublishedAt: now.Add(-2 * time.Hour),
+		},
+	}
+	return &testConfig, testContent, sampleArticles
 }
 
 // TestSMTPConnection validates SMTP configuration by attempting authentication.
@@ -276,16 +775,52 @@ func (s *Service) TestSMTPConnection() error {
 //   - nl2br: Converts newlines to <br> tags for HTML
 //   - add: Addition for template math (e.g., array indexing)
 //
+// The templates are package-level (htmlEmailTemplate, textEmailTemplate) so
+// they can be rendered directly against DossierData fixtures.
+//
 // Parameters:
 //   - data: Structured dossier data for template rendering
 //
 // Returns:
 //   - string: HTML version of email
 //   - string: Plain text version of email
-//   - error: Template parsing or execution failure
+//   - error: Template execution failure
 func (s *Service) generateEmailContent(data DossierData) (string, string, error) {
-	// HTML template
-	htmlTemplate := `
+	var htmlBuf bytes.Buffer
+	if err := htmlEmailTemplate.Execute(&htmlBuf, data); err != nil {
+		return "", "", fmt.Errorf("failed to execute HTML template: %w", err)
+	}
+
+	var textBuf bytes.Buffer
+	if err := textEmailTemplate.Execute(&textBuf, data); err != nil {
+		return "", "", fmt.Errorf("failed to execute text template: %w", err)
+	}
+
+	return htmlBuf.String(), textBuf.String(), nil
+}
+
+// emailTemplateFuncs are the helper functions available to both email
+// templates.
+var emailTemplateFuncs = template.FuncMap{
+	"title": strings.Title,
+	"nl2br": func(text string) template.HTML {
+		return template.HTML(strings.ReplaceAll(text, "\n", "<br>"))
+	},
+	"add": func(a, b int) int {
+		return a + b
+	},
+}
+
+// Parsed once at startup; template.Must turns a syntax error introduced
+// while editing the markup below into an immediate failure rather than a
+// failed delivery. Both templates render a DossierData.
+var (
+	htmlEmailTemplate = template.Must(template.New("html").Funcs(emailTemplateFuncs).Parse(htmlEmailMarkup))
+	textEmailTemplate = template.Must(template.New("text").Funcs(emailTemplateFuncs).Parse(textEmailMarkup))
+)
+
+// htmlEmailMarkup is the HTML email template (see generateEmailContent).
+const htmlEmailMarkup = `
 <!DOCTYPE html>
 <html lang="en">
 <head>
@@ -310,6 +845,7 @@ func (s *Service) generateEmailContent(data DossierData) (string, string, error)
             text-align: center; 
         }
         .header h1 { margin: 0; font-size: 2em; }
+        .greeting { font-size: 1.2em; margin: 0 0 20px; }
         .meta { 
             background: #f8f9fa; 
             padding: 15px; 
@@ -347,6 +883,8 @@ func (s *Service) generateEmailContent(data DossierData) (string, string, error)
             margin-bottom: 10px; 
         }
         .article-description { color: #555; }
+        .article-media { margin-top: 10px; font-size: 0.9em; }
+        .article-media a { color: #667eea; }
         .footer { 
             text-align: center; 
             padding: 20px; 
@@ -372,6 +910,8 @@ func (s *Service) generateEmailContent(data DossierData) (string, string, error)
         <p>Your personalized news dossier</p>
     </div>
 
+    {{if .Greeting}}<p class="greeting">{{.Greeting}}</p>{{end}}
+
     <div class="meta">
         <strong>Generated:</strong> {{.GeneratedAt.Format "Monday, January 2, 2006 at 3:04 PM"}} | 
         <strong>Articles:</strong> {{.ArticleCount}} | 
@@ -397,7 +937,12 @@ func (s *Service) generateEmailContent(data DossierData) (string, string, error)
             </div>
             {{if $article.Description}}
             <div class="article-description">
-                {{$article.Description}}
+                {{if $article.DescriptionHTML}}{{$article.DescriptionHTML}}{{else}}{{$article.Description}}{{end}}
+            </div>
+            {{end}}
+            {{if $article.MediaURL}}
+            <div class="article-media">
+                <a href="{{$article.MediaURL}}" target="_blank">{{$article.MediaLabel}} ▶</a>
             </div>
             {{end}}
         </div>
@@ -405,17 +950,26 @@ func (s *Service) generateEmailContent(data DossierData) (string, string, error)
     </div>
 
     <div class="footer">
+        {{if .FooterText}}
+        <p>{{.FooterText}}</p>
+        {{else}}
         <p>This dossier was automatically generated by <strong>Dossier</strong></p>
         <p>Delivered with ❤️ from your personal news automation system</p>
+        {{end}}
+        {{if .UnsubscribeURL}}
+        <p><a href="{{.ManageURL}}">Manage delivery</a> | <a href="{{.UnsubscribeURL}}">Unsubscribe</a></p>
+        {{end}}
     </div>
 </body>
 </html>`
 
-	// Text template
-	textTemplate := `
+// textEmailMarkup is the plain text email template (see generateEmailContent).
+const textEmailMarkup = `
 {{.Title}}
 ==============================================
-
+{{if .Greeting}}
+{{.Greeting}}
+{{end}}
 Generated: {{.GeneratedAt.Format "Monday, January 2, 2006 at 3:04 PM"}}
 Articles: {{.ArticleCount}} | Style: {{.Tone | title}} {{.Language}}
 {{if .Instructions}}Special Instructions: {{.Instructions}}{{end}}
@@ -430,50 +984,19 @@ ARTICLES
 {{add $index 1}}. {{$article.Title}}
    Source: {{$article.Source}} | Published: {{$article.PublishedAt.Format "Jan 2, 2006"}}
    {{if $article.Description}}{{$article.Description}}{{end}}
-   Read more: {{$article.URL}}
+   {{if $.CTALabel}}{{$.CTALabel}}{{else}}Read more{{end}}: {{$article.URL}}{{if $article.MediaURL}}
+   {{$article.MediaLabel}}: {{$article.MediaURL}}{{end}}
 
 {{end}}
 
 ----------------------------------------------
-This dossier was automatically generated by Dossier
+{{if .FooterText}}{{.FooterText}}
+{{else}}This dossier was automatically generated by Dossier
 Delivered from your personal news automation system
-`
-
-	// Create template functions
-	funcMap := template.FuncMap{
-		"title": strings.Title,
-		"nl2br": func(text string) template.HTML {
-			return template.HTML(strings.ReplaceAll(text, "\n", "<br>"))
-		},
-		"add": func(a, b int) int {
-			return a + b
-		},
-	}
-
-	// Parse and execute HTML template
-	htmlTmpl, err := template.New("html").Funcs(funcMap).Parse(htmlTemplate)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to parse HTML template: %w", err)
-	}
-
-	var htmlBuf bytes.Buffer
-	if err := htmlTmpl.Execute(&htmlBuf, data); err != nil {
-		return "", "", fmt.Errorf("failed to execute HTML template: %w", err)
-	}
-
-	// Parse and execute text template
-	textTmpl, err := template.New("text").Funcs(funcMap).Parse(textTemplate)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to parse text template: %w", err)
-	}
-
-	var textBuf bytes.Buffer
-	if err := textTmpl.Execute(&textBuf, data); err != nil {
-		return "", "", fmt.Errorf("failed to execute text template: %w", err)
-	}
-
-	return htmlBuf.String(), textBuf.String(), nil
-}
+{{end}}{{if .UnsubscribeURL}}
+Manage delivery: {{.ManageURL}}
+Unsubscribe: {{.UnsubscribeURL}}
+{{end}}`
 
 // ============================================================================
 // SMTP OPERATIONS
@@ -483,27 +1006,108 @@ Delivered from your personal news automation system
 //
 // Steps:
 //  1. Build MIME multi-part message (HTML + text)
-//  2. Select appropriate TLS method (STARTTLS or direct)
-//  3. Authenticate with SMTP server
-//  4. Transmit message
+//  2. Enforce the EMAIL_MAX_BYTES size limit (degrading content if needed)
+//  3. Select appropriate TLS method (STARTTLS or direct)
+//  4. Authenticate with SMTP server
+//  5. Transmit message, retrying transient failures (see sendWithRetries)
 //
 // Parameters:
+//   - ctx: Context that cancels pending retries
 //   - email: Complete email with HTML and text bodies
 //
 // Returns:
-//   - error: SMTP connection or delivery failure
-func (s *Service) sendEmail(email DossierEmail) error {
-	message := s.buildMIMEMessage(email)
+//   - error: ErrEmailTooLarge, SMTP connection, or delivery failure
+func (s *Service) sendEmail(ctx context.Context, email DossierEmail) error {
+	message, err := s.buildSizeLimitedMessage(email)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEmailFailed, err)
+	}
 
-	err := s.sendSMTPWithTLS(s.config.FromEmail, []string{email.To}, []byte(message))
+	err = s.sendWithRetries(ctx, email.To, func() error {
+		return s.sendSMTPWithTLS(s.config.EnvelopeFrom, email.envelopeRecipients(), []byte(message))
+	})
 	if err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+		return fmt.Errorf("%w: %w", ErrEmailFailed, err)
 	}
 
 	log.Printf("Successfully sent dossier email to %s", email.To)
 	return nil
 }
 
+// envelopeRecipients returns the SMTP RCPT TO list: the visible recipient
+// followed by any blind copies, which appear in no header.
+func (email DossierEmail) envelopeRecipients() []string {
+	return append([]string{email.To}, email.Bcc...)
+}
+
+// buildSizeLimitedMessage builds the MIME message, degrading content when it
+// exceeds the configured size limit rather than letting the SMTP server bounce it.
+//
+// Degradation Steps (each applied only if still over the limit, and each
+// keeping the reductions before it):
+//  1. Drop attachments (the PDF copy duplicates the body)
+//  2. Re-render both bodies with embedded images stripped from the summary
+//     HTML, sanitized HTML descriptions replaced by their plain text, and
+//     descriptions shortened to reducedDescriptionLength
+//  3. Re-render again with article descriptions omitted entirely
+//
+// Parameters:
+//   - email: Email to build
+//
+// Returns:
+//   - string: MIME message within the size limit
+//   - error: ErrEmailTooLarge if even the fully degraded message is too big
+func (s *Service) buildSizeLimitedMessage(email DossierEmail) (string, error) {
+	message := s.buildMIMEMessage(email)
+	limit := s.config.MaxEmailBytes
+	if limit <= 0 || len(message) <= limit {
+		return message, nil
+	}
+
+	if len(email.Attachments) > 0 {
+		log.Printf("Warning: email to %s is %d bytes (limit %d), dropping attachments", email.To, len(message), limit)
+		email.Attachments = nil
+		message = s.buildMIMEMessage(email)
+		if len(message) <= limit {
+			return message, nil
+		}
+	}
+
+	log.Printf("Warning: email to %s is %d bytes (limit %d), removing images and shortening descriptions",
+		email.To, len(message), limit)
+
+	data := email.DossierData
+	data.Summary = imgTagPattern.ReplaceAllString(data.Summary, "")
+
+	for _, descriptionLength := range []int{reducedDescriptionLength, 0} {
+		articles := make([]ArticleData, len(data.Articles))
+		for i, article := range data.Articles {
+			article.Description = truncateDescription(article.Description, descriptionLength)
+			article.DescriptionHTML = ""
+			articles[i] = article
+		}
+		data.Articles = articles
+
+		htmlBody, textBody, err := s.generateEmailContent(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to regenerate reduced email content: %w", err)
+		}
+
+		reduced := email
+		reduced.HTMLBody = htmlBody
+		reduced.TextBody = textBody
+		reduced.DossierData = data
+
+		message = s.buildMIMEMessage(reduced)
+		if len(message) <= limit {
+			log.Printf("Reduced email to %s to %d bytes", email.To, len(message))
+			return message, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %d bytes (limit %d)", ErrEmailTooLarge, len(message), limit)
+}
+
 // buildMIMEMessage creates a properly formatted MIME multi-part message
 // containing both HTML and plain text versions.
 //
@@ -512,6 +1116,9 @@ func (s *Service) sendEmail(email DossierEmail) error {
 //   - text/plain: First alternative (fallback)
 //   - text/html: Second alternative (preferred)
 //
+// With attachments, the alternative part is nested in a multipart/mixed
+// message followed by one base64-encoded part per attachment.
+//
 // Email Client Behavior:
 //   - Modern clients: Display HTML version
 //   - Basic clients: Display plain text version
@@ -525,10 +1132,14 @@ func (s *Service) sendEmail(email DossierEmail) error {
 func (s *Service) buildMIMEMessage(email DossierEmail) string {
 	boundary := "boundary-dossier-" + fmt.Sprintf("%d", time.Now().Unix())
 
-	message := fmt.Sprintf(`From: %s <%s>
+	if len(email.Attachments) > 0 {
+		return buildMixedMessage(s.fromHeader(), email, boundary)
+	}
+
+	message := fmt.Sprintf(`From: %s
 To: %s
 Subject: %s
-MIME-Version: 1.0
+%sMIME-Version: 1.0
 Content-Type: multipart/alternative; boundary="%s"
 
 --%s
@@ -544,150 +1155,111 @@ Content-Transfer-Encoding: 7bit
 %s
 
 --%s--
-`, s.config.FromName, s.config.FromEmail, email.To, email.Subject,
+`, s.fromHeader(), email.To, email.Subject, listUnsubscribeHeaders(email),
 		boundary, boundary, email.TextBody, boundary, email.HTMLBody, boundary)
 
 	return message
 }
 
-// sendSMTPWithTLS sends an email using the appropriate TLS method based on port.
+// fromHeader formats the From header value from SMTP_FROM_NAME and
+// SMTP_FROM_EMAIL. The display name is quoted when it contains special
+// characters and RFC 2047-encoded when it contains non-ASCII characters
+// ("Résumé Dossier" becomes =?utf-8?q?R=C3=A9sum=C3=A9_Dossier?=), so
+// international sender names arrive intact.
 //
-// Port-Based Strategy:
-//   - 587: STARTTLS (RFC 3207) - Upgrade plain connection
-//   - 465: Direct TLS (SMTPS) - TLS from connection start
-//   - Other: Attempt STARTTLS as safest fallback
+// Returns:
+//   - string: Header value such as "Dossier" <dossier@example.com>
+func (s *Service) fromHeader() string {
+	from := mail.Address{Name: strings.TrimSpace(s.config.FromName), Address: s.config.FromEmail}
+	return from.String()
+}
+
+// sendSMTPWithTLS sends one email over its own connection. The connection is
+// opened by dialSMTP, the same as a Batch's, so single and batched sends
+// negotiate TLS and authenticate identically.
 //
 // Parameters:
-//   - from: Sender email address
+//   - from: Envelope sender (MAIL FROM), normally Config.EnvelopeFrom
 //   - to: List of recipient email addresses
 //   - msg: Complete RFC-compliant email message
 //
 // Returns:
 //   - error: Connection, authentication, or transmission failure
 func (s *Service) sendSMTPWithTLS(from string, to []string, msg []byte) error {
-	addr := s.config.SMTPHost + ":" + s.config.SMTPPort
-	auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.SMTPHost)
+	client, err := s.dialSMTP()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
 
-	if s.config.SMTPPort == "587" {
-		return s.sendWithSTARTTLS(from, to, msg, auth, addr)
+	if err := s.sendMessage(client, from, to, msg); err != nil {
+		return err
+	}
+	// The message was accepted at the end of DATA; a failed QUIT must not
+	// turn it into a retry and a duplicate email
+	if err := client.Quit(); err != nil {
+		log.Printf("SMTP QUIT failed after sending: %v", err)
 	}
-	return s.sendWithDirectTLS(from, to, msg, auth, addr)
+	return nil
 }
 
 // ============================================================================
 // TLS CONNECTION METHODS
 // ============================================================================
 
-// sendWithSTARTTLS sends email using STARTTLS protocol (RFC 3207).
-// This is the modern standard for secure SMTP on port 587.
+// dialSMTP opens an authenticated SMTP connection. It is the only connection
+// path for sending: sendSMTPWithTLS and Batch both use it.
 //
-// STARTTLS Protocol Flow:
-//  1. Connect to SMTP server over plain TCP
-//  2. Issue EHLO command to discover capabilities
-//  3. Send STARTTLS command to upgrade connection
-//  4. Perform TLS handshake
-//  5. Authenticate over encrypted connection
-//  6. Send email data
+// Port-Based Strategy:
+//   - 587: STARTTLS (RFC 3207) - Connect over plain TCP, issue STARTTLS,
+//     then perform the TLS handshake
+//   - 465: Direct TLS (SMTPS) - TLS from connection start, no plaintext phase
+//   - Other: Direct TLS
 //
 // Security Features:
 //   - Certificate validation (InsecureSkipVerify: false)
 //   - Server name verification (SNI)
-//   - Prevents downgrade attacks
-//
-// Parameters:
-//   - from: Sender email address
-//   - to: List of recipient addresses
-//   - msg: Complete email message
-//   - auth: SMTP authentication credentials
-//   - addr: Server address (host:port)
-//
-// Returns:
-//   - error: Connection, TLS, authentication, or transmission failure
-func (s *Service) sendWithSTARTTLS(from string, to []string, msg []byte, auth smtp.Auth, addr string) error {
-	// Establish plain TCP connection
-	client, err := smtp.Dial(addr)
-	if err != nil {
-		return fmt.Errorf("failed to connect to SMTP server: %w", err)
-	}
-	defer client.Quit()
-
-	// Configure TLS with certificate validation
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-		ServerName:         s.config.SMTPHost,
-	}
-
-	// Upgrade connection to TLS
-	if err := client.StartTLS(tlsConfig); err != nil {
-		return fmt.Errorf("failed to start TLS: %w", err)
-	}
-
-	// Authenticate over encrypted connection
-	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
-	}
-
-	// Send the message
-	return s.sendMessage(client, from, to, msg)
-}
-
-// sendWithDirectTLS sends email using direct TLS (SMTPS).
-// This is the traditional secure SMTP on port 465.
-//
-// Direct TLS Protocol Flow:
-//  1. Establish TLS connection immediately
-//  2. Perform TLS handshake before any SMTP commands
-//  3. Create SMTP client over TLS connection
-//  4. Authenticate over encrypted connection
-//  5. Send email data
-//
-// Differences from STARTTLS:
-//   - TLS from connection start (no plaintext phase)
-//   - Used on port 465 by convention
-//   - Older but still widely supported
-//
-// Security Features:
-//   - Certificate validation
-//   - Server name verification
-//   - No plaintext exposure
+//   - Authentication only over the encrypted connection
 //
-// Parameters:
-//   - from: Sender email address
-//   - to: List of recipient addresses
-//   - msg: Complete email message
-//   - auth: SMTP authentication credentials
-//   - addr: Server address (host:port)
+// The caller owns the returned client and must Quit or Close it.
 //
 // Returns:
-//   - error: Connection, TLS, authentication, or transmission failure
-func (s *Service) sendWithDirectTLS(from string, to []string, msg []byte, auth smtp.Auth, addr string) error {
-	// Configure TLS with certificate validation
+//   - *smtp.Client: Connected, encrypted, and authenticated client
+//   - error: Connection, TLS, or authentication failure
+func (s *Service) dialSMTP() (*smtp.Client, error) {
+	addr := s.config.SMTPHost + ":" + s.config.SMTPPort
+	auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.SMTPHost)
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: false,
 		ServerName:         s.config.SMTPHost,
 	}
 
-	// Establish TLS connection
-	conn, err := tls.Dial("tcp", addr, tlsConfig)
-	if err != nil {
-		return fmt.Errorf("failed to connect to SMTP server with TLS: %w", err)
-	}
-	defer conn.Close()
-
-	// Create SMTP client over TLS connection
-	client, err := smtp.NewClient(conn, s.config.SMTPHost)
-	if err != nil {
-		return fmt.Errorf("failed to create SMTP client: %w", err)
+	var client *smtp.Client
+	if s.config.SMTPPort == "587" {
+		var err error
+		if client, err = smtp.Dial(addr); err != nil {
+			return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	} else {
+		conn, err := tls.Dial("tcp", addr, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to SMTP server with TLS: %w", err)
+		}
+		if client, err = smtp.NewClient(conn, s.config.SMTPHost); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to create SMTP client: %w", err)
+		}
 	}
-	defer client.Quit()
 
-	// Authenticate
 	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
+		client.Close()
+		return nil, fmt.Errorf("SMTP authentication failed: %w", err)
 	}
-
-	// Send the message
-	return s.sendMessage(client, from, to, msg)
+	return client, nil
 }
 
 // testWithSTARTTLS tests SMTP connectivity using STARTTLS protocol.
@@ -806,54 +1378,159 @@ func (s *Service) sendMessage(client *smtp.Client, from string, to []string, msg
 	if err != nil {
 		return fmt.Errorf("failed to get data writer: %w", err)
 	}
-	defer writer.Close()
 
 	if _, err := writer.Write(msg); err != nil {
+		writer.Close()
 		return fmt.Errorf("failed to write message: %w", err)
 	}
 
+	// Closing ends DATA; the server accepts or rejects the message here
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
 	return nil
 }
 
 // ============================================================================
-// UTILITY FUNCTIONS
+// BATCH DELIVERY
 // ============================================================================
 
-// extractDomain extracts a clean domain name from a URL for display purposes.
-// This provides user-friendly source attribution in emails.
+// Batch delivers several dossiers over one authenticated SMTP connection
+// instead of connecting and authenticating once per message, which is slow
+// and rate-limited by some providers when many configs share a delivery time.
 //
-// Processing Steps:
-//  1. Remove protocol (http:// or https://)
-//  2. Extract hostname (everything before first /)
-//  3. Remove www. prefix if present
+// The connection is opened on the first send and kept until Close. Before
+// each later message an RSET clears the previous transaction; if it fails
+// (typically because the server dropped the connection while the next
+// dossier was still generating) the batch reconnects and carries on.
+// Transient send failures are retried on a fresh connection, as for
+// single sends. A Batch is safe for concurrent use; sends are serialized.
+type Batch struct {
+	service *Service
+	mutex   sync.Mutex
+	client  *smtp.Client
+	sent    int
+}
+
+// BatchSendEnabled reports whether SMTP_BATCH_SEND is on.
+func (s *Service) BatchSendEnabled() bool {
+	return s.config.BatchSend
+}
+
+// NewBatch starts a batch send. No connection is made until the first message.
 //
-// Examples:
-//   - "https://www.example.com/article" → "example.com"
-//   - "http://blog.site.org/post/123" → "blog.site.org"
-//   - "example.com" → "example.com"
+// Returns:
+//   - *Batch: Batch to send through; call Close when done
+func (s *Service) NewBatch() *Batch {
+	return &Batch{service: s}
+}
+
+// SendDossier renders and sends a dossier over the batch's connection.
+// It has the same signature and size handling as Service.SendDossier.
 //
 // Parameters:
-//   - url: Full article URL
+//   - ctx: Context that cancels pending send retries (releasing the batch)
+//   - config: Dossier configuration with recipient and settings
+//   - summary: AI-generated summary HTML
+//   - articles: Articles included in the dossier
 //
 // Returns:
-//   - string: Clean domain name for display
-func extractDomain(url string) string {
-	// Remove protocol
-	if strings.HasPrefix(url, "http://") {
-		url = url[7:]
-	} else if strings.HasPrefix(url, "https://") {
-		url = url[8:]
-	}
-
-	// Extract hostname
-	parts := strings.Split(url, "/")
-	if len(parts) > 0 {
-		domain := parts[0]
-		// Remove www. prefix
-		if strings.HasPrefix(domain, "www.") {
-			domain = domain[4:]
+//   - error: Rendering, ErrEmailTooLarge, connection, or delivery failure
+func (b *Batch) SendDossier(ctx context.Context, config *models.DossierConfig, summary string, articles []models.Article) error {
+	log.Printf("Preparing to send dossier email: %s to %s (batched)", config.Title, config.Email)
+
+	email, err := b.service.buildDossierEmail(config, summary, articles)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEmailFailed, err)
+	}
+	message, err := b.service.buildSizeLimitedMessage(email)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEmailFailed, err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.client != nil {
+		if err := b.client.Reset(); err != nil {
+			log.Printf("SMTP batch connection lost (%v), reconnecting", err)
+			b.client.Close()
+			b.client = nil
+		}
+	}
+	err = b.service.sendWithRetries(ctx, email.To, func() error {
+		if b.client == nil {
+			client, err := b.service.dialSMTP()
+			if err != nil {
+				return err
+			}
+			b.client = client
+		}
+		err := b.service.sendMessage(b.client, b.service.config.EnvelopeFrom, email.envelopeRecipients(), []byte(message))
+		if err != nil {
+			// The transaction failed midway; start the next one on a clean connection
+			b.client.Close()
+			b.client = nil
 		}
-		return domain
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEmailFailed, err)
+	}
+	b.sent++
+
+	log.Printf("Successfully sent dossier email to %s", email.To)
+	return nil
+}
+
+// Close ends the batch, sending QUIT if a connection is open. It is safe to
+// call on a batch that never sent anything.
+func (b *Batch) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.client == nil {
+		return
+	}
+	if err := b.client.Quit(); err != nil {
+		b.client.Close()
+	}
+	b.client = nil
+	log.Printf("Closed SMTP batch connection after %d message(s)", b.sent)
+}
+
+// ============================================================================
+// UTILITY FUNCTIONS
+// ============================================================================
+
+// truncateDescription shortens an article description for display in the email.
+// Cuts happen at the last word boundary before the limit and are marked with an
+// ellipsis, so verbose feeds that put the whole article in the description
+// don't bloat the email.
+//
+// Examples (maxLength 20):
+//   - "Short text" → "Short text"
+//   - "The quick brown fox jumps over" → "The quick brown fox…"
+//
+// Parameters:
+//   - description: Original RSS description
+//   - maxLength: Maximum characters; 0 omits, negative disables truncation
+//
+// Returns:
+//   - string: Description suitable for the email template
+func truncateDescription(description string, maxLength int) string {
+	description = strings.TrimSpace(description)
+	if maxLength == 0 {
+		return ""
+	}
+	if maxLength < 0 || utf8.RuneCountInString(description) <= maxLength {
+		return description
+	}
+
+	runes := []rune(description)
+	cut := string(runes[:maxLength])
+	if idx := strings.LastIndexAny(cut, " \t\n"); idx > 0 {
+		cut = cut[:idx]
 	}
-	return url
+	return strings.TrimRight(cut, " \t\n.,;:") + "…"
 }
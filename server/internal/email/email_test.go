@@ -0,0 +1,188 @@
+package email
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sampleDossierData returns a fully populated template fixture with two
+// articles and special instructions.
+func sampleDossierData() DossierData {
+	return DossierData{
+		Title:        "Morning Brief",
+		Summary:      "First line of the summary.\nSecond line of the summary.",
+		GeneratedAt:  time.Date(2025, time.March, 3, 7, 30, 0, 0, time.UTC),
+		ArticleCount: 2,
+		Tone:         "professional",
+		Language:     "English",
+		Instructions: "Focus on renewable energy",
+		Articles: []ArticleData{
+			{
+				Title:       "Solar output hits record",
+				Description: "Grid operators report a new peak.",
+				URL:         "https://example.com/solar",
+				Source:      "example.com",
+				PublishedAt: time.Date(2025, time.March, 2, 18, 0, 0, 0, time.UTC),
+			},
+			{
+				Title:       "Wind farm approved",
+				URL:         "https://news.org/wind",
+				Source:      "news.org",
+				PublishedAt: time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+}
+
+func TestGenerateEmailContent(t *testing.T) {
+	s := &Service{}
+
+	t.Run("full dossier", func(t *testing.T) {
+		htmlBody, textBody, err := s.generateEmailContent(sampleDossierData())
+		if err != nil {
+			t.Fatalf("generateEmailContent returned error: %v", err)
+		}
+
+		for _, want := range []string{
+			"<title>Morning Brief</title>",
+			"Monday, March 3, 2025 at 7:30 AM",
+			"First line of the summary.<br>Second line of the summary.", // nl2br
+			"Professional English",                                      // title
+			"Special Instructions:</strong> Focus on renewable energy",
+			`href="https://example.com/solar"`,
+			"Solar output hits record",
+			"Wind farm approved",
+		} {
+			if !strings.Contains(htmlBody, want) {
+				t.Errorf("HTML body missing %q", want)
+			}
+		}
+
+		for _, want := range []string{
+			"Morning Brief",
+			"Articles: 2 | Style: Professional English",
+			"Special Instructions: Focus on renewable energy",
+			"1. Solar output hits record", // add
+			"2. Wind farm approved",
+			"Read more: https://example.com/solar",
+		} {
+			if !strings.Contains(textBody, want) {
+				t.Errorf("text body missing %q", want)
+			}
+		}
+	})
+
+	t.Run("no instructions", func(t *testing.T) {
+		data := sampleDossierData()
+		data.Instructions = ""
+		htmlBody, textBody, err := s.generateEmailContent(data)
+		if err != nil {
+			t.Fatalf("generateEmailContent returned error: %v", err)
+		}
+		if strings.Contains(htmlBody, "Special Instructions") {
+			t.Error("HTML body shows special instructions when none were set")
+		}
+		if strings.Contains(textBody, "Special Instructions") {
+			t.Error("text body shows special instructions when none were set")
+		}
+	})
+
+	t.Run("no articles", func(t *testing.T) {
+		data := sampleDossierData()
+		data.Articles = nil
+		data.ArticleCount = 0
+		htmlBody, textBody, err := s.generateEmailContent(data)
+		if err != nil {
+			t.Fatalf("generateEmailContent returned error: %v", err)
+		}
+		if strings.Contains(htmlBody, `<div class="article">`) {
+			t.Error("HTML body renders an article card with no articles")
+		}
+		if strings.Contains(textBody, "1. ") {
+			t.Error("text body numbers an article with no articles")
+		}
+		if !strings.Contains(textBody, "Articles: 0") {
+			t.Error("text body missing the zero article count")
+		}
+	})
+
+	t.Run("zero generated time", func(t *testing.T) {
+		data := sampleDossierData()
+		data.GeneratedAt = time.Time{}
+		htmlBody, textBody, err := s.generateEmailContent(data)
+		if err != nil {
+			t.Fatalf("generateEmailContent returned error: %v", err)
+		}
+		for name, body := range map[string]string{"HTML": htmlBody, "text": textBody} {
+			if !strings.Contains(body, "January 1, 0001") {
+				t.Errorf("%s body does not render the zero time", name)
+			}
+		}
+	})
+}
+
+// fromHeaderLine returns the value of the From header of a built message.
+func fromHeaderLine(t *testing.T, message string) string {
+	t.Helper()
+	for _, line := range strings.Split(message, "\n") {
+		if value, ok := strings.CutPrefix(line, "From: "); ok {
+			return value
+		}
+		if line == "" {
+			break
+		}
+	}
+	t.Fatal("message has no From header")
+	return ""
+}
+
+func TestFromHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		fromName string
+		want     string
+	}{
+		{"ascii", "Dossier", `"Dossier" <dossier@example.com>`},
+		{"specials quoted", `Dossier, "Daily" Edition`, `"Dossier, \"Daily\" Edition" <dossier@example.com>`},
+		{"accented encoded", "Résumé Dossier", "=?utf-8?q?R=C3=A9sum=C3=A9_Dossier?= <dossier@example.com>"},
+		{"accented and quoted", `Café "Le Monde"`, "=?utf-8?b?Q2Fmw6kgIkxlIE1vbmRlIg==?= <dossier@example.com>"}, // b-encoding is shorter here
+		{"surrounding spaces trimmed", "  Dossier  ", `"Dossier" <dossier@example.com>`},
+		{"no display name", "", "<dossier@example.com>"},
+	}
+
+	email := DossierEmail{
+		To:       "reader@example.com",
+		Subject:  "Morning Brief",
+		HTMLBody: "<p>Hello</p>",
+		TextBody: "Hello",
+	}
+	withAttachment := email
+	withAttachment.Attachments = []Attachment{{Filename: "dossier.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{config: Config{FromEmail: "dossier@example.com", FromName: tt.fromName}}
+
+			for kind, message := range map[string]string{
+				"buildMIMEMessage":  s.buildMIMEMessage(email),
+				"buildMixedMessage": buildMixedMessage(s.fromHeader(), withAttachment, "test-boundary"),
+			} {
+				got := fromHeaderLine(t, message)
+				if got != tt.want {
+					t.Errorf("%s From = %q, want %q", kind, got, tt.want)
+				}
+
+				// The header must decode back to the configured sender
+				address, err := mail.ParseAddress(got)
+				if err != nil {
+					t.Fatalf("%s From %q does not parse: %v", kind, got, err)
+				}
+				if address.Name != strings.TrimSpace(tt.fromName) || address.Address != "dossier@example.com" {
+					t.Errorf("%s From decodes to %q <%s>", kind, address.Name, address.Address)
+				}
+			}
+		})
+	}
+}
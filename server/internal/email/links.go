@@ -0,0 +1,152 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// SIGNED LINKS
+// ============================================================================
+
+// Actions a signed link can authorize. A token is only valid for the action
+// it was issued for, so an unsubscribe link cannot be replayed to resume
+// deliveries.
+const (
+	LinkUnsubscribe = "unsubscribe" // Deactivate the configuration
+	LinkManage      = "manage"      // View the configuration and pause or resume it
+)
+
+const (
+	// defaultLinkTTL is how long signed links stay valid when LINK_TTL is not
+	// set; long enough for a weekly or monthly digest to sit unread
+	defaultLinkTTL = 30 * 24 * time.Hour
+
+	// minLinkSecretLength is the shortest LINK_SIGNING_SECRET accepted
+	// without a warning
+	minLinkSecretLength = 32
+)
+
+// ErrInvalidLink is returned for a token that is malformed, was signed with
+// another secret, was altered, or was issued for a different action.
+var ErrInvalidLink = errors.New("invalid link")
+
+// ErrLinkExpired is returned for a correctly signed token past its expiry.
+var ErrLinkExpired = errors.New("link has expired")
+
+// LinksEnabled reports whether dossier emails carry signed unsubscribe and
+// manage links: PUBLIC_URL and LINK_SIGNING_SECRET must both be set.
+//
+// Returns:
+//   - bool: true if links can be signed and verified
+func (s *Service) LinksEnabled() bool {
+	return s.config.PublicURL != "" && len(s.config.LinkSecret) > 0
+}
+
+// SignLink issues a token authorizing one action on one configuration until
+// LINK_TTL from now.
+//
+// Token Format:
+// base64url("<configID>.<action>.<expiry unix>") + "." + base64url(HMAC-SHA256)
+// The payload is readable but cannot be changed without the server secret.
+//
+// Parameters:
+//   - configID: Configuration the link acts on
+//   - action: LinkUnsubscribe or LinkManage
+//   - now: Issue time
+//
+// Returns:
+//   - string: URL-safe token
+func (s *Service) SignLink(configID int, action string, now time.Time) string {
+	payload := fmt.Sprintf("%d.%s.%d", configID, action, now.Add(s.config.LinkTTL).Unix())
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(s.linkMAC(payload))
+}
+
+// VerifyLink checks a token's signature, action, and expiry.
+//
+// Parameters:
+//   - token: Token from SignLink
+//   - action: Action the caller is about to perform
+//   - now: Current time
+//
+// Returns:
+//   - int: Configuration ID the token authorizes
+//   - error: ErrInvalidLink or ErrLinkExpired
+func (s *Service) VerifyLink(token, action string, now time.Time) (int, error) {
+	if !s.LinksEnabled() {
+		return 0, ErrInvalidLink
+	}
+
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrInvalidLink
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, ErrInvalidLink
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, s.linkMAC(string(payload))) {
+		return 0, ErrInvalidLink
+	}
+
+	// The signature is valid, so the payload is one SignLink produced
+	parts := strings.Split(string(payload), ".")
+	if len(parts) != 3 || parts[1] != action {
+		return 0, ErrInvalidLink
+	}
+	configID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, ErrInvalidLink
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidLink
+	}
+	if now.Unix() > expires {
+		return 0, ErrLinkExpired
+	}
+	return configID, nil
+}
+
+// linkURL builds the public URL of a signed link, such as
+// https://dossier.example.com/unsubscribe?token=...
+//
+// Parameters:
+//   - configID: Configuration the link acts on
+//   - action: LinkUnsubscribe or LinkManage (also the URL path)
+//   - now: Issue time
+//
+// Returns:
+//   - string: Absolute URL, or "" when links are disabled
+func (s *Service) linkURL(configID int, action string, now time.Time) string {
+	if !s.LinksEnabled() {
+		return ""
+	}
+	return s.config.PublicURL + "/" + action + "?token=" + url.QueryEscape(s.SignLink(configID, action, now))
+}
+
+// linkMAC signs a link payload with LINK_SIGNING_SECRET.
+func (s *Service) linkMAC(payload string) []byte {
+	mac := hmac.New(sha256.New, s.config.LinkSecret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// listUnsubscribeHeaders returns the List-Unsubscribe header lines (with the
+// RFC 8058 one-click marker) for an email that has an unsubscribe link, or
+// "" when it has none.
+func listUnsubscribeHeaders(email DossierEmail) string {
+	if email.Unsubscribe == "" {
+		return ""
+	}
+	return fmt.Sprintf("List-Unsubscribe: <%s>\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\n", email.Unsubscribe)
+}
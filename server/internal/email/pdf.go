@@ -0,0 +1,176 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ============================================================================
+// PDF ATTACHMENTS
+// ============================================================================
+
+// pdfRenderTimeout bounds a single HTML-to-PDF conversion. Renderers fetch
+// the dossier's remote images, so a slow image host must not stall delivery.
+const pdfRenderTimeout = 60 * time.Second
+
+// ErrPDFUnavailable is returned when a PDF copy is requested but no
+// PDF_RENDER_COMMAND is configured.
+var ErrPDFUnavailable = errors.New("PDF rendering is not configured (set PDF_RENDER_COMMAND)")
+
+// renderPDFAttachment converts the dossier's HTML body to a PDF attachment.
+//
+// Rendering is delegated to the external PDF_RENDER_COMMAND (for example
+// wkhtmltopdf or a headless Chrome wrapper), which receives the HTML on stdin
+// and must write the PDF to stdout. Keeping the renderer out of process means
+// builds and deployments without one still work; attach_pdf configs then
+// simply receive the HTML email.
+//
+// Parameters:
+//   - title: Dossier title, used for the attachment's filename
+//   - htmlBody: Rendered HTML email body
+//   - generatedAt: Generation time, used for the attachment's filename
+//
+// Returns:
+//   - Attachment: application/pdf attachment
+//   - error: ErrPDFUnavailable, a renderer failure, or a PDF over PDF_MAX_BYTES
+func (s *Service) renderPDFAttachment(title, htmlBody string, generatedAt time.Time) (Attachment, error) {
+	command := s.config.PDFRenderCommand
+	if len(command) == 0 {
+		return Attachment{}, ErrPDFUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pdfRenderTimeout)
+	defer cancel()
+
+	output := &cappedBuffer{limit: s.config.MaxPDFBytes}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = strings.NewReader(htmlBody)
+	cmd.Stdout = output
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if output.exceeded {
+			return Attachment{}, fmt.Errorf("PDF exceeds PDF_MAX_BYTES (%d bytes)", s.config.MaxPDFBytes)
+		}
+		return Attachment{}, fmt.Errorf("PDF renderer failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if !bytes.HasPrefix(output.buf.Bytes(), []byte("%PDF")) {
+		return Attachment{}, fmt.Errorf("PDF renderer did not write a PDF to stdout")
+	}
+
+	return Attachment{
+		Filename:    pdfFilename(title, generatedAt),
+		ContentType: "application/pdf",
+		Data:        output.buf.Bytes(),
+	}, nil
+}
+
+// cappedBuffer collects renderer output, failing the write (and so the
+// renderer) once more than limit bytes arrive. A limit of 0 disables the cap.
+//
+// The buffer is a named field rather than embedded so bytes.Buffer's ReadFrom
+// is not promoted; io.Copy would otherwise use it and bypass the cap.
+type cappedBuffer struct {
+	buf      bytes.Buffer
+	limit    int
+	exceeded bool
+}
+
+// Write implements io.Writer.
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.limit > 0 && b.buf.Len()+len(p) > b.limit {
+		b.exceeded = true
+		return 0, errors.New("output too large")
+	}
+	return b.buf.Write(p)
+}
+
+// pdfFilename builds an attachment filename such as
+// "morning-briefing-2025-03-14.pdf" from a dossier title and date.
+func pdfFilename(title string, generatedAt time.Time) string {
+	var slug strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			slug.WriteRune(r)
+			dash = false
+		} else if !dash && slug.Len() > 0 {
+			slug.WriteByte('-')
+			dash = true
+		}
+	}
+	name := strings.TrimSuffix(slug.String(), "-")
+	if name == "" {
+		name = "dossier"
+	}
+	return fmt.Sprintf("%s-%s.pdf", name, generatedAt.Format("2006-01-02"))
+}
+
+// buildMixedMessage creates a multipart/mixed MIME message: the usual
+// text/HTML alternative part followed by each attachment, base64-encoded in
+// 76-character lines.
+//
+// Parameters:
+//   - from: Encoded From header value (see fromHeader)
+//   - email: Email with bodies and attachments
+//   - boundary: MIME boundary; the nested alternative part uses "alt-" + boundary
+//
+// Returns:
+//   - string: Complete RFC-compliant MIME message
+func buildMixedMessage(from string, email DossierEmail, boundary string) string {
+	altBoundary := "alt-" + boundary
+
+	var message strings.Builder
+	fmt.Fprintf(&message, `From: %s
+To: %s
+Subject: %s
+%sMIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="%s"
+
+--%s
+Content-Type: multipart/alternative; boundary="%s"
+
+--%s
+Content-Type: text/plain; charset=UTF-8
+Content-Transfer-Encoding: 7bit
+
+%s
+
+--%s
+Content-Type: text/html; charset=UTF-8
+Content-Transfer-Encoding: 7bit
+
+%s
+
+--%s--
+`, from, email.To, email.Subject, listUnsubscribeHeaders(email), boundary,
+		boundary, altBoundary, altBoundary, email.TextBody, altBoundary, email.HTMLBody, altBoundary)
+
+	for _, attachment := range email.Attachments {
+		fmt.Fprintf(&message, `
+--%s
+Content-Type: %s; name="%s"
+Content-Disposition: attachment; filename="%s"
+Content-Transfer-Encoding: base64
+
+`, boundary, attachment.ContentType, attachment.Filename, attachment.Filename)
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for len(encoded) > 76 {
+			message.WriteString(encoded[:76] + "\n")
+			encoded = encoded[76:]
+		}
+		message.WriteString(encoded + "\n")
+	}
+
+	fmt.Fprintf(&message, "\n--%s--\n", boundary)
+	return message.String()
+}
@@ -0,0 +1,109 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/textproto"
+	"os"
+	"time"
+)
+
+// ============================================================================
+// SEND RETRIES
+// ============================================================================
+
+const (
+	// defaultSendAttempts is the number of tries per message when
+	// SMTP_SEND_ATTEMPTS is not set
+	defaultSendAttempts = 3
+
+	// defaultSendRetryDelay is the wait before the first retry when
+	// SMTP_RETRY_DELAY is not set; it doubles for each later retry
+	defaultSendRetryDelay = 30 * time.Second
+)
+
+// sendWithRetries runs send up to Config.SendAttempts times, backing off
+// between tries, as long as each failure is transient.
+//
+// Greylisting servers answer the first delivery attempt from an unknown
+// sender with a 4xx reply and accept the same message a little later, and a
+// dropped connection or TLS hiccup usually clears on reconnect. Permanent
+// rejects (5xx, such as an unknown recipient or failed authentication) and
+// local errors are returned immediately, since retrying cannot fix them.
+//
+// The wait between attempts ends early when ctx is done, so a cancelled run
+// (or a batch holding its connection lock) is not kept waiting for a retry
+// that no longer matters.
+//
+// Parameters:
+//   - ctx: Caller's context; cancelling it abandons the remaining retries
+//   - recipient: Visible recipient, for logging
+//   - send: One complete delivery attempt (connect, authenticate, transmit)
+//
+// Returns:
+//   - error: The last attempt's error (nil once an attempt succeeds), also
+//     wrapping ctx.Err() when the retries were abandoned
+func (s *Service) sendWithRetries(ctx context.Context, recipient string, send func() error) error {
+	delay := s.config.SendRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := send()
+		if err == nil || attempt >= s.config.SendAttempts || !isTransientSMTPError(err) {
+			return err
+		}
+		log.Printf("Transient SMTP error sending to %s (attempt %d of %d), retrying in %s: %v",
+			recipient, attempt, s.config.SendAttempts, delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("%w (retries abandoned: %w)", err, ctx.Err())
+		}
+		delay *= 2
+	}
+}
+
+// isTransientSMTPError reports whether a failed send is worth retrying:
+// SMTP 4xx replies (temporary failures, including greylisting) and
+// network-level failures such as refused or reset connections and timeouts.
+// 5xx replies are permanent.
+//
+// Parameters:
+//   - err: Error from a send attempt
+//
+// Returns:
+//   - bool: true if the same message may succeed on a later attempt
+func isTransientSMTPError(err error) bool {
+	var reply *textproto.Error
+	if errors.As(err, &reply) {
+		return reply.Code >= 400 && reply.Code < 500
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// getEnvDurationOrDefault retrieves a Go duration environment variable or
+// returns a default. Unparseable or negative values are logged and replaced
+// by the default.
+//
+// Parameters:
+//   - key: Environment variable name
+//   - defaultValue: Fallback value if variable is not set or invalid
+//
+// Returns:
+//   - time.Duration: Parsed environment variable value or default
+func getEnvDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		log.Printf("Invalid %s %q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
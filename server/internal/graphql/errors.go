@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/geraldfingburke/dossier/server/internal/ai"
+	"github.com/geraldfingburke/dossier/server/internal/email"
+	"github.com/geraldfingburke/dossier/server/internal/rss"
+	"github.com/geraldfingburke/dossier/server/internal/scheduler"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// ============================================================================
+// ERROR CODES
+// ============================================================================
+
+// errorCodes maps the services' sentinel errors to the stable codes clients
+// receive in extensions.code, checked in order. Earlier entries win, so a
+// generation cut short by its deadline reports TIMEOUT even though it also
+// wraps ai.ErrSummaryFailed.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{scheduler.ErrGenerationInProgress, "GENERATION_IN_PROGRESS"},
+	{scheduler.ErrNotResendable, "NOT_RESENDABLE"},
+	{rss.ErrNoArticles, "NO_ARTICLES"},
+	{rss.ErrTooFewArticles, "TOO_FEW_ARTICLES"},
+	{context.DeadlineExceeded, "TIMEOUT"},
+	{ai.ErrSummaryFailed, "SUMMARY_FAILED"},
+	{email.ErrEmailFailed, "EMAIL_FAILED"},
+}
+
+// errorCode returns the stable code for an error, or "" when it matches none
+// of errorCodes.
+//
+// Parameters:
+//   - err: Error returned by a resolver or a generation job (may be nil)
+//
+// Returns:
+//   - string: Code such as "NO_ARTICLES"
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, entry := range errorCodes {
+		if errors.Is(err, entry.err) {
+			return entry.code
+		}
+	}
+	return ""
+}
+
+// formatError is the handler's FormatErrorFn. Errors that already carry
+// extensions (such as *ValidationError) keep them; otherwise a known
+// sentinel error adds {"code": ...}, so the UI can show "no news today"
+// differently from "the AI is down" without matching English messages.
+//
+// Parameters:
+//   - err: Error from query execution (resolver errors arrive as *gqlerrors.Error)
+//
+// Returns:
+//   - gqlerrors.FormattedError: Error as sent to the client
+func formatError(err error) gqlerrors.FormattedError {
+	formatted := gqlerrors.FormatError(err)
+	if formatted.Extensions != nil {
+		return formatted
+	}
+
+	cause := err
+	var gqlErr *gqlerrors.Error
+	if errors.As(err, &gqlErr) && gqlErr.OriginalError != nil {
+		cause = gqlErr.OriginalError
+	}
+	if code := errorCode(cause); code != "" {
+		formatted.Extensions = map[string]interface{}{"code": code}
+	}
+	return formatted
+}
@@ -34,45 +34,80 @@
 // Types:
 //   - DossierConfig: User configuration for automated digests
 //   - Dossier: Historical delivery record
+//   - DeliverySearchResult: Ranked full-text match with a highlighted snippet
 //   - Tone: AI tone preset with system/custom variants
+//   - ConfigToneStatus: Whether a configuration's tone still exists
+//   - TonePromptValidation: Sample output and HTML warnings for a tone prompt
+//   - Feed: Shared feed that configurations reference by ID
 //   - SchedulerStatus: Real-time scheduler information
+//   - SelectionPreview: Explained article selection for a configuration
 //
 // Queries:
 //   - dossierConfigs: List all active configurations
 //   - dossierConfig(id): Get single configuration by ID
 //   - dossiers(configId, limit): Query delivery history
+//   - searchDeliveries(query, configId, limit): Full-text search over deliveries
 //   - tones: List all available AI tones
 //   - tone(id): Get single tone by ID
+//   - configToneStatus(configId): Check that a configuration's tone resolves
+//   - validateTonePrompt(prompt): Check a tone prompt's sample output for broken HTML
+//   - feeds: List shared feeds
+//   - feed(id): Get single feed by ID
 //   - schedulerStatus: Current scheduler state
+//   - generationJob(id): Status of an asynchronous generation job
+//   - previewSelection(configId): AI article selection with a reason per pick
 //
 // Mutations:
 //   - createDossierConfig: Create new configuration
 //   - updateDossierConfig: Update existing configuration
 //   - deleteDossierConfig: Delete configuration
-//   - generateAndSendDossier: Manually trigger delivery
+//   - generateAndSendDossier: Manually trigger delivery (synchronous)
+//   - queueDossierGeneration: Queue delivery and return a job ID
+//   - generateAllActive: Trigger delivery for every active config (admin only)
+//   - resendDelivery: Resend a generated dossier whose email failed
 //   - sendTestEmail: Send test email with sample data
 //   - testEmailConnection: Validate SMTP settings
 //   - createTone: Create custom AI tone
 //   - updateTone: Update custom tone
 //   - deleteTone: Delete custom tone (system defaults protected)
+//   - createFeed / updateFeed / deleteFeed: Manage shared feeds
 package graphql
 
 import (
+	"context"
+	"crypto/subtle"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/geraldfingburke/dossier/server/internal/ai"
+	"github.com/geraldfingburke/dossier/server/internal/database"
 	"github.com/geraldfingburke/dossier/server/internal/email"
 	"github.com/geraldfingburke/dossier/server/internal/models"
 	"github.com/geraldfingburke/dossier/server/internal/rss"
 	"github.com/geraldfingburke/dossier/server/internal/scheduler"
+	"github.com/geraldfingburke/dossier/server/internal/textutil"
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/handler"
 	"github.com/lib/pq"
 )
 
+const (
+	// defaultSearchLimit is the number of searchDeliveries results returned
+	// when no limit is given
+	defaultSearchLimit = 20
+
+	// maxSearchLimit caps searchDeliveries results
+	maxSearchLimit = 100
+)
+
 // ============================================================================
 // GRAPHQL HANDLER
 // ============================================================================
@@ -94,6 +129,11 @@ import (
 //   - Pretty: Formatted JSON responses for readability
 //   - GraphiQL: Interactive GraphQL IDE enabled for development
 //
+// Environment Variables:
+//   - MAX_FEEDS_PER_CONFIG: Most feeds (feedUrls and feedIds combined) a
+//     configuration may have; larger configs are rejected on create, update,
+//     and import (default: 25)
+//
 // Parameters:
 //   - db: Database connection
 //   - rssService: RSS feed service
@@ -105,6 +145,14 @@ import (
 //   - *handler.Handler: Configured GraphQL HTTP handler
 //   - error: Schema creation or validation error
 func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailService *email.Service, schedulerService *scheduler.Service) (*handler.Handler, error) {
+	if value := os.Getenv("MAX_FEEDS_PER_CONFIG"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			maxFeedsPerConfig = parsed
+		} else {
+			log.Printf("Invalid MAX_FEEDS_PER_CONFIG %q, using default %d", value, defaultMaxFeedsPerConfig)
+		}
+	}
+
 	// ========================================================================
 	// TYPE DEFINITIONS
 	// ========================================================================
@@ -128,6 +176,35 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	//   - specialInstructions: Custom AI instructions
 	//   - active: Whether automated delivery is enabled
 	//   - createdAt: Configuration creation timestamp
+	//   - rollupSourceId: Config whose deliveries this rollup summarizes (null for regular configs)
+	//   - interests: Reader interests used to rank articles by relevance
+	//   - enforceLanguage: Whether output language is verified and translated if needed
+	//   - includeExecutiveSummary: Generate and include the executive summary section
+	//   - includeConclusion: Generate and include the conclusion section
+	//   - skipWeekends: Skip scheduled deliveries on weekends
+	//   - skipDates: Dates (YYYY-MM-DD) on which scheduled delivery is skipped
+	//   - feedIds: IDs of shared feeds aggregated alongside feedUrls
+	//   - minArticles: Fewest fetched articles worth sending
+	//   - deliveryWeekdays: Days a weekly config delivers on (0=Sunday..6=Saturday)
+	//   - maxPerSource: Most articles taken from any one domain (0 = no limit)
+	//   - ctaLabel: Article "read more" link text (empty = default)
+	//   - footerText: Email footer line (empty = default)
+	//   - articlesOnlyFallback: Send an articles-only email when summary generation fails
+	//   - orderByImportance: Articles ordered by AI importance ranking, top story badged
+	//   - preserveTitles: Article titles kept verbatim when summarizing into another language
+	//   - attachPdf: Whether a PDF copy of the dossier is attached to each email
+	//   - sandboxSends: Sandbox deliveries remaining before the normal schedule resumes (0 = off)
+	//   - pipeline: Generation pipeline ("robust" or "simple")
+	//   - temperature: Sampling temperature override (null = server default)
+	//   - useFeedContent: Feed-provided article text is used instead of scraping
+	//   - splitByCategory: One dossier is sent per feed category
+	//   - recipientName: Name greeted at the top of each email (empty = no greeting)
+	//   - sinceLastDelivery: Only articles published after the last sent dossier are included
+	//   - sponsoredFilter: "drop", "demote", or "off" for sponsored/ad-labeled feed items
+	//   - sponsoredPatterns: Patterns marking sponsored items (empty = server defaults)
+	//   - distribution: "even", "priority", or "newest" division of articleCount between feeds
+	//   - toneIntensity: "subtle", "normal", or "strong" application of the tone
+	//   - candidatePoolSize: Articles ranked by selection; those beyond articleCount are "also noted" (0 = articleCount)
 	dossierConfigType := graphql.NewObject(graphql.ObjectConfig{
 		Name: "DossierConfig",
 		Fields: graphql.Fields{
@@ -191,6 +268,93 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 			"createdAt": &graphql.Field{
 				Type: graphql.NewNonNull(graphql.String),
 			},
+			"rollupSourceId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"interests": &graphql.Field{
+				Type: graphql.String,
+			},
+			"enforceLanguage": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"includeExecutiveSummary": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"includeConclusion": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"skipWeekends": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"skipDates": &graphql.Field{
+				Type: graphql.NewList(graphql.String),
+			},
+			"feedIds": &graphql.Field{
+				Type: graphql.NewList(graphql.Int),
+			},
+			"minArticles": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"deliveryWeekdays": &graphql.Field{
+				Type: graphql.NewList(graphql.Int),
+			},
+			"maxPerSource": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"ctaLabel": &graphql.Field{
+				Type: graphql.String,
+			},
+			"footerText": &graphql.Field{
+				Type: graphql.String,
+			},
+			"articlesOnlyFallback": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"orderByImportance": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"preserveTitles": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"attachPdf": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"sandboxSends": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"pipeline": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"temperature": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"useFeedContent": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"splitByCategory": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"recipientName": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"sinceLastDelivery": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"sponsoredFilter": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"sponsoredPatterns": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			},
+			"distribution": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"toneIntensity": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"candidatePoolSize": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
 		},
 	})
 
@@ -203,6 +367,35 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	//   - tone: "professional" (applied in resolver)
 	//   - language: "English" (applied in resolver)
 	//   - specialInstructions: "" (empty string)
+	//   - rollupSourceId: null (regular feed-based config)
+	//   - interests: "" (no relevance ranking)
+	//   - enforceLanguage: false
+	//   - includeExecutiveSummary: true
+	//   - includeConclusion: true
+	//   - skipWeekends: false
+	//   - skipDates: []
+	//   - feedIds: []
+	//   - minArticles: 1
+	//   - deliveryWeekdays: Weekly delivery days, 0=Sunday..6=Saturday (optional, default Mondays)
+	//   - maxPerSource: Per-domain article cap (optional, default 0 = no limit)
+	//   - ctaLabel: "Read on MyCompany News →" (optional, default "Read full article")
+	//   - footerText: Custom footer line (optional, default Dossier footer)
+	//   - articlesOnlyFallback: false
+	//   - orderByImportance: false
+	//   - preserveTitles: false
+	//   - attachPdf: false
+	//   - sandboxSends: 0 (sandbox off)
+	//   - pipeline: "robust"
+	//   - temperature: null (OLLAMA_TEMPERATURE or the model default)
+	//   - useFeedContent: false
+	//   - splitByCategory: false
+	//   - recipientName: "" (no greeting)
+	//   - sinceLastDelivery: false
+	//   - sponsoredFilter: "drop"
+	//   - sponsoredPatterns: [] (server defaults)
+	//   - distribution: "even"
+	//   - toneIntensity: "normal"
+	//   - candidatePoolSize: 0 (articleCount, nothing "also noted")
 	dossierConfigInputType := graphql.NewInputObject(graphql.InputObjectConfig{
 		Name: "DossierConfigInput",
 		Fields: graphql.InputObjectConfigFieldMap{
@@ -236,6 +429,93 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 			"specialInstructions": &graphql.InputObjectFieldConfig{
 				Type: graphql.String,
 			},
+			"rollupSourceId": &graphql.InputObjectFieldConfig{
+				Type: graphql.Int,
+			},
+			"interests": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"enforceLanguage": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"includeExecutiveSummary": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"includeConclusion": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"skipWeekends": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"skipDates": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewList(graphql.String),
+			},
+			"feedIds": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewList(graphql.Int),
+			},
+			"minArticles": &graphql.InputObjectFieldConfig{
+				Type: graphql.Int,
+			},
+			"deliveryWeekdays": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewList(graphql.Int),
+			},
+			"maxPerSource": &graphql.InputObjectFieldConfig{
+				Type: graphql.Int,
+			},
+			"ctaLabel": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"footerText": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"articlesOnlyFallback": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"orderByImportance": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"preserveTitles": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"attachPdf": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"sandboxSends": &graphql.InputObjectFieldConfig{
+				Type: graphql.Int,
+			},
+			"pipeline": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"temperature": &graphql.InputObjectFieldConfig{
+				Type: graphql.Float,
+			},
+			"useFeedContent": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"splitByCategory": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"recipientName": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"sinceLastDelivery": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			"sponsoredFilter": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"sponsoredPatterns": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewList(graphql.NewNonNull(graphql.String)),
+			},
+			"distribution": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"toneIntensity": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"candidatePoolSize": &graphql.InputObjectFieldConfig{
+				Type: graphql.Int,
+			},
 		},
 	})
 
@@ -246,8 +526,10 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	//
 	// Fields:
 	//   - running: Whether the scheduler is actively running
-	//   - nextCheck: Timestamp of next scheduled check (currently null, TODO)
+	//   - nextCheck: Timestamp of next scheduled check (RFC3339, null when stopped)
 	//   - activeDossiers: Count of enabled dossier configurations
+	//   - inFlightGenerations: Generations currently queued or running
+	//   - maxConcurrentGenerations: Concurrency limit for generations
 	schedulerStatusType := graphql.NewObject(graphql.ObjectConfig{
 		Name: "SchedulerStatus",
 		Fields: graphql.Fields{
@@ -260,6 +542,180 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 			"activeDossiers": &graphql.Field{
 				Type: graphql.NewNonNull(graphql.Int),
 			},
+			"inFlightGenerations": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"maxConcurrentGenerations": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+		},
+	})
+
+	// ServerSetting GraphQL type is one effective server setting.
+	//
+	// Fields:
+	//   - group: Component that reads it: database, ai, email, rss, or scheduler
+	//   - name: Environment variable (or a description for fixed settings)
+	//   - value: Effective value, with secrets redacted
+	//   - fromEnv: Whether the environment variable was set (false = built-in default)
+	serverSettingType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ServerSetting",
+		Fields: graphql.Fields{
+			"group": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"value": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"fromEnv": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+		},
+	})
+
+	// GenerationTriggerSummary GraphQL type reports the result of a batch trigger.
+	//
+	// Fields:
+	//   - total: Number of active configurations considered
+	//   - triggered: Configurations queued for generation
+	//   - skipped: Configurations skipped because a generation was already in flight
+	generationTriggerSummaryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "GenerationTriggerSummary",
+		Fields: graphql.Fields{
+			"total": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"triggered": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"skipped": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+		},
+	})
+
+	// GenerationJob GraphQL type reports progress of an asynchronous generation.
+	//
+	// Fields:
+	//   - id: Job identifier
+	//   - configId: Configuration being generated
+	//   - status: queued, running, succeeded, or failed
+	//   - error: Failure message when status is failed
+	//   - errorCode: Stable failure code (see errors.go), e.g. NO_ARTICLES
+	//   - createdAt/startedAt/finishedAt: Lifecycle timestamps (RFC3339)
+	generationJobType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "GenerationJob",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+			},
+			"configId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+			},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"error": &graphql.Field{
+				Type: graphql.String,
+			},
+			"errorCode": &graphql.Field{
+				Type: graphql.String,
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"startedAt": &graphql.Field{
+				Type: graphql.String,
+			},
+			"finishedAt": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	})
+
+	// SelectionPick GraphQL type is one article chosen by previewSelection.
+	//
+	// Fields:
+	//   - rank: Position in the selection (1 = most important or relevant)
+	//   - title: Article title
+	//   - link: Article URL
+	//   - source: Article domain
+	//   - rationale: The model's reason for the pick ("" when selection was not applied)
+	selectionPickType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SelectionPick",
+		Fields: graphql.Fields{
+			"rank": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"title": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"link": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"source": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"rationale": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+	})
+
+	// SelectionPreview GraphQL type reports the article selection a
+	// configuration's next generation would make.
+	//
+	// Fields:
+	//   - configId: Configuration previewed
+	//   - candidateCount: Articles offered to selection
+	//   - selectionApplied: Whether the model chose among the candidates (false
+	//     when there are too few to need selection and all are used)
+	//   - picks: Selected articles in ranked order
+	selectionPreviewType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SelectionPreview",
+		Fields: graphql.Fields{
+			"configId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+			},
+			"candidateCount": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"selectionApplied": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"picks": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(selectionPickType))),
+			},
+		},
+	})
+
+	// GenerationUsage GraphQL type is the work one delivery's generation did,
+	// for sizing article counts and pipelines on limited hardware. Deliveries
+	// recorded before usage was tracked report zeros.
+	//
+	// Fields:
+	//   - llmCalls: Model calls made, including retries
+	//   - tokens: Prompt plus response tokens (reported by Ollama or estimated)
+	//   - pagesScraped: Article pages requested
+	//   - durationMs: Wall-clock time of the whole run in milliseconds
+	generationUsageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "GenerationUsage",
+		Fields: graphql.Fields{
+			"llmCalls": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"tokens": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"pagesScraped": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"durationMs": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
 		},
 	})
 
@@ -274,6 +730,9 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	//   - subject: Email subject line (derived from config title)
 	//   - content: AI-generated summary content
 	//   - sentAt: Delivery timestamp
+	//   - usage: Work the generation did (model calls, tokens, scrapes, duration)
+	//   - error: Why generation or sending failed (null for successful deliveries)
+	//   - resendable: Generated but not sent; resendDelivery can send it
 	dossierType := graphql.NewObject(graphql.ObjectConfig{
 		Name: "Dossier",
 		Fields: graphql.Fields{
@@ -292,6 +751,70 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 			"sentAt": &graphql.Field{
 				Type: graphql.NewNonNull(graphql.String),
 			},
+			"usage": &graphql.Field{
+				Type: graphql.NewNonNull(generationUsageType),
+			},
+			"error": &graphql.Field{
+				Type: graphql.String,
+			},
+			"resendable": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+		},
+	})
+
+	// DeliverySearchResult GraphQL type is one match from searchDeliveries.
+	//
+	// Fields:
+	//   - id: Delivery identifier (same as Dossier.id)
+	//   - configId: Reference to the dossier configuration
+	//   - subject: Configuration title
+	//   - sentAt: Delivery timestamp
+	//   - rank: Full-text relevance score (higher is better)
+	//   - snippet: Plain-text excerpt with matches wrapped in <mark> tags
+	deliverySearchResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "DeliverySearchResult",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+			},
+			"configId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+			},
+			"subject": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"sentAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"rank": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Float),
+			},
+			"snippet": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+	})
+
+	// ConfigReference GraphQL type identifies a configuration that uses some
+	// other object (for example a tone) without loading the whole config.
+	//
+	// Fields:
+	//   - id: Configuration ID
+	//   - title: Configuration title
+	//   - active: Whether the configuration is delivering
+	configReferenceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ConfigReference",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+			},
+			"title": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"active": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
 		},
 	})
 
@@ -305,6 +828,7 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	//   - name: Display name (e.g., "Professional", "Casual", "Pirate")
 	//   - prompt: AI instruction text for tone application
 	//   - isSystemDefault: Whether this is a protected system tone
+	//   - configs: Configurations that reference this tone by name
 	//   - createdAt: Tone creation timestamp
 	//   - updatedAt: Last modification timestamp
 	//
@@ -328,12 +852,80 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 			"isSystemDefault": &graphql.Field{
 				Type: graphql.NewNonNull(graphql.Boolean),
 			},
-			"createdAt": &graphql.Field{
-				Type: graphql.NewNonNull(graphql.String),
-			},
-			"updatedAt": &graphql.Field{
-				Type: graphql.NewNonNull(graphql.String),
-			},
+			"configs": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(configReferenceType))),
+				// Lists every configuration (active or not) whose tone is this
+				// tone's name, so a tone's users are visible before it is
+				// renamed or deleted.
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var name string
+					switch v := p.Source.(type) {
+					case *models.Tone:
+						name = v.Name
+					case models.Tone:
+						name = v.Name
+					default:
+						return nil, fmt.Errorf("unexpected source type: %T", v)
+					}
+					return configsUsingTone(p.Context, db, name)
+				},
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"updatedAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+	})
+
+	// ConfigToneStatus GraphQL type reports whether a configuration's tone
+	// name still resolves to a tone. Unresolved tones silently fall back to
+	// DEFAULT_TONE_PROMPT during generation.
+	//
+	// Fields:
+	//   - configId: Configuration checked
+	//   - tone: Tone name stored on the configuration
+	//   - resolved: Whether a tone with that name exists
+	//   - isSystemDefault: Whether the resolved tone is a system tone (false
+	//     when unresolved)
+	configToneStatusType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ConfigToneStatus",
+		Fields: graphql.Fields{
+			"configId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+			},
+			"tone": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"resolved": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"isSystemDefault": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+		},
+	})
+
+	// TonePromptValidation GraphQL type reports how a tone prompt's sample
+	// output would render in an email.
+	//
+	// Fields:
+	//   - valid: No warnings were found
+	//   - warnings: Problems such as unbalanced tags or unsafe elements
+	//   - sample: The generated sample summary
+	tonePromptValidationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TonePromptValidation",
+		Fields: graphql.Fields{
+			"valid": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"warnings": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			},
+			"sample": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
 		},
 	})
 
@@ -357,6 +949,98 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 		},
 	})
 
+	// Feed GraphQL type represents a shared RSS/Atom feed.
+	//
+	// Feeds can be referenced by ID from any number of dossier configurations
+	// (via feedIds), so metadata such as title and category is kept in one place.
+	//
+	// Fields:
+	//   - id: Unique feed identifier
+	//   - url: Feed URL (unique)
+	//   - title: Display title
+	//   - description: Optional description
+	//   - category: Free-form grouping label
+	//   - priority: Weight (1-10) for configurations using the "priority" distribution
+	//   - active: Inactive feeds are skipped by every configuration using them
+	//   - lastFetched: Most recent successful fetch (null if never fetched)
+	//   - createdAt: Feed creation timestamp
+	//   - updatedAt: Last modification timestamp
+	feedType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Feed",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"url": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"title": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"description": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"category": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"priority": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"active": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+			},
+			"lastFetched": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					feed, ok := p.Source.(models.Feed)
+					if !ok || feed.LastFetched == nil {
+						return nil, nil
+					}
+					return feed.LastFetched.UTC().Format(time.RFC3339), nil
+				},
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"updatedAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+	})
+
+	// FeedInput GraphQL input type for feed create/update mutations.
+	//
+	// Fields:
+	//   - url: Feed URL (required)
+	//   - title: Display title (optional)
+	//   - description: Description (optional)
+	//   - category: Grouping label (optional)
+	//   - priority: Weight for the "priority" distribution (optional, 1-10, default 1)
+	//   - active: Whether the feed is used (optional, default true)
+	feedInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "FeedInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"url": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"title": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"description": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"category": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"priority": &graphql.InputObjectFieldConfig{
+				Type: graphql.Int,
+			},
+			"active": &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+		},
+	})
+
 	// ========================================================================
 	// QUERY OPERATIONS
 	// ========================================================================
@@ -366,10 +1050,18 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	// Query operations provide data retrieval without side effects:
 	//   - dossierConfigs: List all active configurations
 	//   - dossierConfig: Get single configuration by ID
+	//   - exportDossierConfig: Export a configuration as portable JSON
 	//   - schedulerStatus: Get scheduler state and active count
 	//   - dossiers: Query delivery history with optional filtering
+	//   - searchDeliveries: Full-text search over delivery content
 	//   - tones: List all available AI tones
 	//   - tone: Get single tone by ID
+	//   - configToneStatus: Check that a configuration's tone resolves
+	//   - validateTonePrompt: Check a tone prompt's sample output for broken HTML
+	//   - feeds: List shared feeds
+	//   - feed: Get single feed by ID
+	//   - summarizeURL: Summarize a single article URL with a tone
+	//   - previewSelection: Explain which articles generation would select
 	rootQuery := graphql.NewObject(graphql.ObjectConfig{
 		Name: "Query",
 		Fields: graphql.Fields{
@@ -383,9 +1075,7 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - Sorted by created_at descending (newest first)
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					rows, err := db.QueryContext(p.Context, `
-						SELECT id, title, email, feed_urls, article_count, frequency, 
-							   delivery_time::text, timezone, tone, language, special_instructions, 
-							   active, created_at
+						SELECT `+database.ConfigColumns+`
 						FROM dossier_configs
 						WHERE active = true
 						ORDER BY created_at DESC
@@ -398,10 +1088,7 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 					var configs []models.DossierConfig
 					for rows.Next() {
 						var config models.DossierConfig
-						err := rows.Scan(&config.ID, &config.Title, &config.Email, pq.Array(&config.FeedURLs),
-							&config.ArticleCount, &config.Frequency, &config.DeliveryTime,
-							&config.Timezone, &config.Tone, &config.Language,
-							&config.SpecialInstructions, &config.Active, &config.CreatedAt)
+						err := database.ScanConfig(rows, &config)
 						if err != nil {
 							return nil, err
 						}
@@ -427,18 +1114,16 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - null if ID doesn't exist
 				//   - error for database issues
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					id := p.Args["id"].(string)
+					id, err := idArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
 
 					var config models.DossierConfig
-					err := db.QueryRowContext(p.Context, `
-						SELECT id, title, email, feed_urls, article_count, frequency, 
-							   delivery_time::text, timezone, tone, language, special_instructions, 
-							   active, created_at
+					err = database.ScanConfig(db.QueryRowContext(p.Context, `
+						SELECT `+database.ConfigColumns+`
 						FROM dossier_configs WHERE id = $1
-					`, id).Scan(&config.ID, &config.Title, &config.Email, pq.Array(&config.FeedURLs),
-						&config.ArticleCount, &config.Frequency, &config.DeliveryTime,
-						&config.Timezone, &config.Tone, &config.Language,
-						&config.SpecialInstructions, &config.Active, &config.CreatedAt)
+					`, id), &config)
 					if err != nil {
 						if err == sql.ErrNoRows {
 							return nil, nil
@@ -448,36 +1133,218 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 					return &config, nil
 				},
 			},
+			"exportDossierConfig": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.ID),
+					},
+				},
+				// Exports a configuration as a portable JSON document for backup
+				// or for moving it to another instance with importDossierConfig.
+				//
+				// Arguments:
+				//   - id: Configuration ID (required)
+				//
+				// Returns:
+				//   - JSON document without server-specific IDs (feed IDs become
+				//     URLs, the tone and rollup source are referenced by name)
+				//   - null if ID doesn't exist
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
+					document, err := exportDossierConfig(p.Context, db, id)
+					if err != nil || document == "" {
+						return nil, err
+					}
+					return document, nil
+				},
+			},
 			"schedulerStatus": &graphql.Field{
 				Type: schedulerStatusType,
-				// Retrieves current scheduler status and counts active dossiers.
+				// Retrieves a consistent snapshot of the scheduler's state.
 				//
 				// Returns:
 				//   - running: Boolean indicating if scheduler is active
-				//   - nextCheck: Next scheduled check time (currently null/TODO)
+				//   - nextCheck: Next scheduled check time (null when stopped)
 				//   - activeDossiers: Count of enabled dossier configurations
+				//   - inFlightGenerations: Generations currently queued or running
+				//   - maxConcurrentGenerations: Concurrency limit for generations
 				//
 				// Use Cases:
 				//   - Admin dashboard monitoring
 				//   - System health checks
 				//   - User feedback on automation status
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					// Count active dossier configs
-					var activeCount int
-					err := db.QueryRowContext(p.Context, `
-						SELECT COUNT(*) FROM dossier_configs WHERE active = true
-					`).Scan(&activeCount)
+					snapshot, err := schedulerService.Snapshot(p.Context)
 					if err != nil {
 						return nil, err
 					}
 
+					var nextCheck interface{}
+					if snapshot.NextCheck != nil {
+						nextCheck = snapshot.NextCheck.UTC().Format(time.RFC3339)
+					}
+
 					return map[string]interface{}{
-						"running":        schedulerService.IsRunning(),
-						"nextCheck":      nil, // TODO: implement next check time
-						"activeDossiers": activeCount,
+						"running":                  snapshot.Running,
+						"nextCheck":                nextCheck,
+						"activeDossiers":           snapshot.ActiveConfigs,
+						"inFlightGenerations":      snapshot.InFlightGenerations,
+						"maxConcurrentGenerations": snapshot.MaxConcurrent,
 					}, nil
 				},
 			},
+			"serverConfig": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(serverSettingType))),
+				// Reports the effective server configuration: what each
+				// component actually uses after reading its environment
+				// variables and applying defaults. Secrets (SMTP_PASSWORD,
+				// LINK_SIGNING_SECRET) are reported only as set or not set,
+				// and passwords in URLs are masked.
+				//
+				// Returns:
+				//   - List of ServerSetting, grouped by component
+				//   - error if the caller is not an admin
+				//
+				// Use Cases:
+				//   - Checking that environment variables were loaded (a
+				//     setting with fromEnv false is at its default)
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := requireAdmin(p.Context); err != nil {
+						return nil, err
+					}
+
+					groups := []struct {
+						name     string
+						settings []models.Setting
+					}{
+						{"database", database.Settings()},
+						{"ai", aiService.Settings()},
+						{"email", emailService.Settings()},
+						{"rss", rssService.Settings()},
+						{"scheduler", schedulerService.Settings()},
+					}
+					settings := []map[string]interface{}{}
+					for _, group := range groups {
+						for _, setting := range group.settings {
+							settings = append(settings, serverSettingFields(group.name, setting))
+						}
+					}
+					return settings, nil
+				},
+			},
+			"previewSelection": &graphql.Field{
+				Type: selectionPreviewType,
+				Args: graphql.FieldConfigArgument{
+					"configId": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.ID),
+					},
+				},
+				// Fetches a configuration's articles and runs only the AI
+				// article selection step, with the model asked to explain
+				// each pick. Nothing is scraped, summarized, sent, or recorded.
+				//
+				// Arguments:
+				//   - configId: Configuration ID (required; inactive configs allowed)
+				//
+				// Returns:
+				//   - SelectionPreview with ranked picks and rationales
+				//   - null if the configuration doesn't exist
+				//   - error for rollup configs, fetch failures, or a failed selection call
+				//
+				// Use Cases:
+				//   - Tuning interests and selection-related special instructions
+				//   - Understanding why a story made (or missed) a dossier
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					configID, err := idArg(p, "configId")
+					if err != nil {
+						return nil, err
+					}
+
+					var config models.DossierConfig
+					err = database.ScanConfig(db.QueryRowContext(p.Context, `
+						SELECT `+database.ConfigColumns+`
+						FROM dossier_configs WHERE id = $1
+					`, configID), &config)
+					if err == sql.ErrNoRows {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					if config.IsRollup() {
+						return nil, fmt.Errorf("rollup configurations summarize past deliveries and do not select articles")
+					}
+
+					feedURLs, err := database.ResolveFeedURLs(p.Context, db, &config)
+					if err != nil {
+						return nil, err
+					}
+					articles, err := schedulerService.CollectArticles(p.Context, config, feedURLs, time.Time{})
+					if err != nil {
+						return nil, err
+					}
+					if len(articles) == 0 {
+						return nil, rss.ErrNoArticles
+					}
+
+					preview, err := aiService.PreviewSelection(p.Context, articles, ai.SummaryOptionsFromConfig(&config))
+					if err != nil {
+						return nil, fmt.Errorf("article selection failed: %w", err)
+					}
+
+					picks := make([]map[string]interface{}, len(preview.Picks))
+					for i, pick := range preview.Picks {
+						picks[i] = map[string]interface{}{
+							"rank":      i + 1,
+							"title":     pick.Article.Title,
+							"link":      pick.Article.Link,
+							"source":    textutil.ExtractDomain(pick.Article.Link),
+							"rationale": pick.Rationale,
+						}
+					}
+					return map[string]interface{}{
+						"configId":         strconv.Itoa(config.ID),
+						"candidateCount":   preview.Candidates,
+						"selectionApplied": preview.Applied,
+						"picks":            picks,
+					}, nil
+				},
+			},
+			"generationJob": &graphql.Field{
+				Type: generationJobType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.ID),
+					},
+				},
+				// Retrieves the status of an asynchronous generation job.
+				//
+				// Jobs are kept in memory, so IDs from before a restart (or old
+				// jobs evicted after many newer ones finish) resolve to null.
+				//
+				// Arguments:
+				//   - id: Job ID returned by queueDossierGeneration
+				//
+				// Returns:
+				//   - GenerationJob if known
+				//   - null if the job doesn't exist or was evicted
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := stringArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
+
+					job, ok := schedulerService.GetJob(id)
+					if !ok {
+						return nil, nil
+					}
+					return generationJobToMap(job), nil
+				},
+			},
 			"dossiers": &graphql.Field{
 				Type: graphql.NewList(dossierType),
 				Args: graphql.FieldConfigArgument{
@@ -503,20 +1370,27 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - Displaying recent deliveries across all dossiers
 				//   - Audit trail for email delivery
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					configId, hasConfigId := p.Args["configId"]
+					_, hasConfigId := p.Args["configId"]
 					limit, hasLimit := p.Args["limit"]
 
 					query := `
-						SELECT dd.id, dd.config_id, dc.title as subject, dd.summary as content, dd.delivery_date
+						SELECT dd.id, dd.config_id, dc.title as subject, dd.summary as content, dd.delivery_date,
+							dd.llm_calls, dd.llm_tokens, dd.pages_scraped, dd.duration_ms, dd.error_message,
+							(NOT dd.email_sent AND dd.error_message IS NOT NULL AND dd.articles IS NOT NULL) AS resendable
 						FROM dossier_deliveries dd
 						JOIN dossier_configs dc ON dd.config_id = dc.id
+						WHERE dd.skip_reason = ''
 					`
 					args := []interface{}{}
 					argIndex := 1
 
 					if hasConfigId {
-						query += " WHERE dd.config_id = $" + fmt.Sprintf("%d", argIndex)
-						args = append(args, configId)
+						configID, err := idArg(p, "configId")
+						if err != nil {
+							return nil, err
+						}
+						query += " AND dd.config_id = $" + fmt.Sprintf("%d", argIndex)
+						args = append(args, configID)
 						argIndex++
 					}
 
@@ -535,26 +1409,138 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 
 					var dossiers []map[string]interface{}
 					for rows.Next() {
-						var id, configId int
+						var id, configId, llmCalls, tokens, pagesScraped int
+						var durationMs int64
 						var subject, content, sentAt string
+						var errorMessage sql.NullString
+						var resendable bool
 
-						err := rows.Scan(&id, &configId, &subject, &content, &sentAt)
+						err := rows.Scan(&id, &configId, &subject, &content, &sentAt,
+							&llmCalls, &tokens, &pagesScraped, &durationMs, &errorMessage, &resendable)
 						if err != nil {
 							return nil, err
 						}
 
-						dossiers = append(dossiers, map[string]interface{}{
+						dossier := map[string]interface{}{
 							"id":       fmt.Sprintf("%d", id),
 							"configId": fmt.Sprintf("%d", configId),
 							"subject":  subject,
 							"content":  content,
 							"sentAt":   sentAt,
-						})
+							"usage": map[string]interface{}{
+								"llmCalls":     llmCalls,
+								"tokens":       tokens,
+								"pagesScraped": pagesScraped,
+								"durationMs":   durationMs,
+							},
+							"resendable": resendable,
+						}
+						if errorMessage.Valid {
+							dossier["error"] = errorMessage.String
+						}
+						dossiers = append(dossiers, dossier)
 					}
 
 					return dossiers, nil
 				},
 			},
+			"searchDeliveries": &graphql.Field{
+				Type: graphql.NewList(deliverySearchResultType),
+				Args: graphql.FieldConfigArgument{
+					"query": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					"configId": &graphql.ArgumentConfig{
+						Type: graphql.ID,
+					},
+					"limit": &graphql.ArgumentConfig{
+						Type: graphql.Int,
+					},
+				},
+				// Full-text search over past delivery content.
+				//
+				// The query uses web-search syntax ("quoted phrases", OR, -exclude)
+				// against the search_vector column (English stemming over the
+				// summary with HTML tags stripped).
+				//
+				// Arguments:
+				//   - query: Search text (required)
+				//   - configId: Restrict to one configuration (optional)
+				//   - limit: Maximum results (optional, default 20, max 100)
+				//
+				// Returns:
+				//   - Matching deliveries ranked by relevance, newest first on ties
+				//   - Each result includes a highlighted snippet
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					search, err := stringArg(p, "query")
+					if err != nil {
+						return nil, err
+					}
+					search = strings.TrimSpace(search)
+					if search == "" {
+						return []map[string]interface{}{}, nil
+					}
+
+					limit := defaultSearchLimit
+					if value, ok := p.Args["limit"].(int); ok && value > 0 {
+						limit = value
+					}
+					if limit > maxSearchLimit {
+						limit = maxSearchLimit
+					}
+
+					var configID interface{}
+					if p.Args["configId"] != nil {
+						id, err := idArg(p, "configId")
+						if err != nil {
+							return nil, err
+						}
+						configID = id
+					}
+
+					rows, err := db.QueryContext(p.Context, `
+						SELECT dd.id, dd.config_id, dc.title, dd.delivery_date,
+							ts_rank(dd.search_vector, q) AS rank,
+							ts_headline('english', regexp_replace(dd.summary, '<[^>]+>', ' ', 'g'), q,
+								'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10')
+						FROM dossier_deliveries dd
+						JOIN dossier_configs dc ON dd.config_id = dc.id,
+							websearch_to_tsquery('english', $1) q
+						WHERE dd.search_vector @@ q
+							AND dd.skip_reason = ''
+							AND ($2::int IS NULL OR dd.config_id = $2::int)
+						ORDER BY rank DESC, dd.delivery_date DESC
+						LIMIT $3
+					`, search, configID, limit)
+					if err != nil {
+						return nil, err
+					}
+					defer rows.Close()
+
+					results := []map[string]interface{}{}
+					for rows.Next() {
+						var id, configId int
+						var subject, snippet string
+						var sentAt time.Time
+						var rank float64
+
+						if err := rows.Scan(&id, &configId, &subject, &sentAt, &rank, &snippet); err != nil {
+							return nil, err
+						}
+
+						results = append(results, map[string]interface{}{
+							"id":       fmt.Sprintf("%d", id),
+							"configId": fmt.Sprintf("%d", configId),
+							"subject":  subject,
+							"sentAt":   sentAt.UTC().Format(time.RFC3339),
+							"rank":     rank,
+							"snippet":  snippet,
+						})
+					}
+
+					return results, rows.Err()
+				},
+			},
 			"tones": &graphql.Field{
 				Type: graphql.NewList(toneType),
 				// Retrieves all available AI tone presets.
@@ -607,9 +1593,12 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - Tone object if found
 				//   - error if ID doesn't exist or database issue
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					id := p.Args["id"].(int)
+					id, err := intArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
 					var tone models.Tone
-					err := db.QueryRowContext(p.Context, `
+					err = db.QueryRowContext(p.Context, `
 						SELECT id, name, prompt, is_system_default, created_at, updated_at 
 						FROM tones WHERE id = $1
 					`, id).Scan(&tone.ID, &tone.Name, &tone.Prompt, &tone.IsSystemDefault, &tone.CreatedAt, &tone.UpdatedAt)
@@ -619,6 +1608,230 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 					return &tone, nil
 				},
 			},
+			"configToneStatus": &graphql.Field{
+				Type: configToneStatusType,
+				Args: graphql.FieldConfigArgument{
+					"configId": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.ID),
+					},
+				},
+				// Reports whether a configuration's tone name resolves to an
+				// existing tone. Generation falls back to DEFAULT_TONE_PROMPT
+				// for unresolved tones, which is otherwise only noticeable from
+				// the output.
+				//
+				// Arguments:
+				//   - configId: Configuration ID (required)
+				//
+				// Returns:
+				//   - ConfigToneStatus for the configuration
+				//   - null if the configuration doesn't exist
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p, "configId")
+					if err != nil {
+						return nil, err
+					}
+
+					var tone string
+					var resolved, isSystemDefault sql.NullBool
+					err = db.QueryRowContext(p.Context, `
+						SELECT dc.tone, t.id IS NOT NULL, t.is_system_default
+						FROM dossier_configs dc
+						LEFT JOIN tones t ON t.name = dc.tone
+						WHERE dc.id = $1
+					`, id).Scan(&tone, &resolved, &isSystemDefault)
+					if err == sql.ErrNoRows {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+
+					return map[string]interface{}{
+						"configId":        fmt.Sprintf("%d", id),
+						"tone":            tone,
+						"resolved":        resolved.Bool,
+						"isSystemDefault": isSystemDefault.Bool,
+					}, nil
+				},
+			},
+			"feeds": &graphql.Field{
+				Type: graphql.NewList(feedType),
+				// Retrieves all shared feeds.
+				//
+				// Returns:
+				//   - List of Feed objects sorted by category, then title and URL
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rows, err := db.QueryContext(p.Context, `
+						SELECT `+database.FeedColumns+`
+						FROM feeds
+						ORDER BY category, title, url
+					`)
+					if err != nil {
+						return nil, err
+					}
+					defer rows.Close()
+
+					var feeds []models.Feed
+					for rows.Next() {
+						var feed models.Feed
+						if err := database.ScanFeed(rows, &feed); err != nil {
+							return nil, err
+						}
+						feeds = append(feeds, feed)
+					}
+					return feeds, rows.Err()
+				},
+			},
+			"feed": &graphql.Field{
+				Type: feedType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+				},
+				// Retrieves a single feed by ID.
+				//
+				// Arguments:
+				//   - id: Feed ID (required)
+				//
+				// Returns:
+				//   - Feed object if found
+				//   - error if ID doesn't exist or database issue
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := intArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
+					var feed models.Feed
+					err = database.ScanFeed(db.QueryRowContext(p.Context, `
+						SELECT `+database.FeedColumns+` FROM feeds WHERE id = $1
+					`, id), &feed)
+					if err != nil {
+						return nil, err
+					}
+					return feed, nil
+				},
+			},
+			"summarizeURL": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"url": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					"tone": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+					"language": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+				},
+				// Summarizes a single article URL outside of any configuration,
+				// using the same scraping, cleaning, and per-article summary
+				// steps as dossier generation.
+				//
+				// Arguments:
+				//   - url: http(s) article URL (required)
+				//   - tone: Tone name (optional, default "professional")
+				//   - language: Summary language (optional, default "English")
+				//
+				// Returns:
+				//   - Summary text
+				//   - error for invalid arguments, unknown tones, scrape or AI failures
+				//
+				// Use Cases:
+				//   - Quick summary of one article
+				//   - Trying out a tone prompt on real content
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					v := newInputValidator(p.Args)
+					articleURL := v.requiredString("url")
+					if articleURL != "" {
+						parsed, err := url.Parse(articleURL)
+						if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+							v.addError("url", "must be an http or https URL")
+						}
+					}
+					tone := strings.TrimSpace(v.optionalString("tone", ""))
+					if tone == "" {
+						tone = "professional"
+					}
+					language := strings.TrimSpace(v.optionalString("language", ""))
+					if language == "" {
+						language = "English"
+					}
+					if err := v.err(); err != nil {
+						return nil, err
+					}
+
+					var exists bool
+					err := db.QueryRowContext(p.Context, `SELECT EXISTS(SELECT 1 FROM tones WHERE name = $1)`, tone).Scan(&exists)
+					if err != nil {
+						return nil, err
+					}
+					if !exists {
+						return nil, argError(p, "tone", fmt.Sprintf("tone %q not found", tone))
+					}
+
+					return aiService.SummarizeURL(p.Context, articleURL, tone, language)
+				},
+			},
+			"validateTonePrompt": &graphql.Field{
+				Type: tonePromptValidationType,
+				Args: graphql.FieldConfigArgument{
+					"prompt": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					"name": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+					"language": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+				},
+				// Runs a quick sample generation with a tone prompt (saved or
+				// not) and checks the output for HTML that would break the
+				// email: unbalanced tags, unsafe elements such as <script> or
+				// <style>, event handlers, and Markdown that shows literally.
+				//
+				// Arguments:
+				//   - prompt: Tone prompt to check (required)
+				//   - name: Tone name it will be saved under; uncensored tone
+				//     names route to the uncensored model (optional)
+				//   - language: Sample language (optional, default "English")
+				//
+				// Returns:
+				//   - TonePromptValidation with the sample and any warnings
+				//   - error for invalid arguments or AI failures
+				//
+				// Use Cases:
+				//   - Checking a custom tone before createTone or updateTone
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					v := newInputValidator(p.Args)
+					prompt := strings.TrimSpace(v.requiredString("prompt"))
+					name := strings.TrimSpace(v.optionalString("name", ""))
+					language := strings.TrimSpace(v.optionalString("language", ""))
+					if language == "" {
+						language = "English"
+					}
+					if err := v.err(); err != nil {
+						return nil, err
+					}
+
+					result, err := aiService.ValidateTonePrompt(p.Context, name, prompt, language)
+					if err != nil {
+						return nil, fmt.Errorf("sample generation failed: %w", err)
+					}
+					warnings := result.Warnings
+					if warnings == nil {
+						warnings = []string{}
+					}
+					return map[string]interface{}{
+						"valid":    len(warnings) == 0,
+						"warnings": warnings,
+						"sample":   result.Sample,
+					}, nil
+				},
+			},
 		},
 	})
 
@@ -632,11 +1845,15 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	//
 	// Dossier Configuration:
 	//   - createDossierConfig: Create new configuration
+	//   - importDossierConfig: Create configuration from exported JSON
 	//   - updateDossierConfig: Update existing configuration
 	//   - deleteDossierConfig: Delete configuration
 	//
 	// Dossier Generation & Delivery:
 	//   - generateAndSendDossier: Manually trigger delivery (fetch, summarize, send)
+	//   - queueDossierGeneration: Queue delivery asynchronously, returning a job
+	//   - generateAllActive: Queue delivery for every active configuration (admin only)
+	//   - resendDelivery: Resend a failed delivery's stored dossier
 	//   - sendTestEmail: Send test email with sample data
 	//   - testEmailConnection: Validate SMTP configuration
 	//
@@ -644,6 +1861,10 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	//   - createTone: Create custom tone
 	//   - updateTone: Update custom tone (system defaults protected)
 	//   - deleteTone: Delete custom tone (system defaults protected)
+	//
+	// Feed Management:
+	//   - createFeed / updateFeed: Manage shared feeds referenced by feedIds
+	//   - deleteFeed: Delete a feed and remove it from configurations
 	rootMutation := graphql.NewObject(graphql.ObjectConfig{
 		Name: "Mutation",
 		Fields: graphql.Fields{
@@ -663,6 +1884,8 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - tone: "professional" if not specified
 				//   - language: "English" if not specified
 				//   - specialInstructions: "" (empty) if not specified
+				//   - interests: "" (no relevance ranking) if not specified
+				//   - enforceLanguage: false if not specified
 				//   - active: true (set by database default)
 				//
 				// Returns:
@@ -673,58 +1896,51 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - Scheduler will begin monitoring this configuration
 				//   - Automated deliveries will start based on frequency and delivery_time
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					input := p.Args["input"].(map[string]interface{})
-
-					title := input["title"].(string)
-					email := input["email"].(string)
-					feedUrls := input["feedUrls"].([]interface{})
-					articleCount := input["articleCount"].(int)
-					frequency := input["frequency"].(string)
-					deliveryTime := input["deliveryTime"].(string)
-					timezone := input["timezone"].(string)
-
-					// Handle optional fields with defaults
-					tone := "professional"
-					if input["tone"] != nil {
-						tone = input["tone"].(string)
-					}
-
-					language := "English"
-					if input["language"] != nil {
-						language = input["language"].(string)
-					}
-
-					specialInstructions := ""
-					if input["specialInstructions"] != nil {
-						specialInstructions = input["specialInstructions"].(string)
+					input, err := inputArg(p, "input")
+					if err != nil {
+						return nil, err
 					}
-
-					// Convert feedUrls from []interface{} to []string
-					feedURLStrings := make([]string, len(feedUrls))
-					for i, url := range feedUrls {
-						feedURLStrings[i] = url.(string)
+					in, err := dossierConfigFromInput(p.Context, db, input, 0)
+					if err != nil {
+						return nil, err
 					}
 
-					var config models.DossierConfig
-					err := db.QueryRowContext(p.Context, `
-						INSERT INTO dossier_configs (title, email, feed_urls, article_count, frequency, 
-							delivery_time, timezone, tone, language, special_instructions)
-						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-						RETURNING id, title, email, feed_urls, article_count, frequency, 
-							delivery_time, timezone, tone, language, special_instructions, 
-							active, created_at
-					`, title, email, pq.Array(feedURLStrings), articleCount, frequency, deliveryTime,
-						timezone, tone, language, specialInstructions).Scan(
-						&config.ID, &config.Title, &config.Email, pq.Array(&config.FeedURLs),
-						&config.ArticleCount, &config.Frequency, &config.DeliveryTime,
-						&config.Timezone, &config.Tone, &config.Language,
-						&config.SpecialInstructions, &config.Active, &config.CreatedAt)
+					config, err := insertDossierConfig(p.Context, db, in)
 					if err != nil {
 						return nil, err
 					}
 
 					log.Printf("Created new dossier config: %s", config.Title)
-					return &config, nil
+					return config, nil
+				},
+			},
+			"importDossierConfig": &graphql.Field{
+				Type: dossierConfigType,
+				Args: graphql.FieldConfigArgument{
+					"json": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+				},
+				// Creates a configuration from an exportDossierConfig document.
+				//
+				// Arguments:
+				//   - json: Exported document (may be edited before import)
+				//
+				// Behavior:
+				//   - Validated exactly like createDossierConfig input
+				//   - Tone matched by name; a missing custom tone is recreated from
+				//     the exported prompt, otherwise "professional" is used
+				//   - Rollup source matched by title (must be unique)
+				//
+				// Returns:
+				//   - Newly created DossierConfig object with generated ID
+				//   - error for malformed documents, validation failures, or database issues
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					document, err := stringArg(p, "json")
+					if err != nil {
+						return nil, err
+					}
+					return importDossierConfig(p.Context, db, document)
 				},
 			},
 			"updateDossierConfig": &graphql.Field{
@@ -744,7 +1960,9 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - input: DossierConfigInput with updated values
 				//
 				// Behavior:
-				//   - Replaces all fields with new values
+				//   - Replaces all fields with new values, except sandboxSends:
+				//     omitted, it keeps the stored count, so editing a config
+				//     mid-sandbox (e.g. to tune its tone) doesn't end sandbox mode
 				//   - Sets updated_at timestamp automatically
 				//   - Applies same default values as createDossierConfig
 				//
@@ -756,56 +1974,53 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - Scheduler will use updated settings for next delivery
 				//   - No impact on already-sent dossiers
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					id := p.Args["id"].(string)
-					input := p.Args["input"].(map[string]interface{})
-
-					title := input["title"].(string)
-					email := input["email"].(string)
-					feedUrls := input["feedUrls"].([]interface{})
-					articleCount := input["articleCount"].(int)
-					frequency := input["frequency"].(string)
-					deliveryTime := input["deliveryTime"].(string)
-					timezone := input["timezone"].(string)
-
-					// Handle optional fields with defaults
-					tone := "professional"
-					if input["tone"] != nil {
-						tone = input["tone"].(string)
+					configID, err := idArg(p, "id")
+					if err != nil {
+						return nil, err
 					}
-
-					language := "English"
-					if input["language"] != nil {
-						language = input["language"].(string)
+					input, err := inputArg(p, "input")
+					if err != nil {
+						return nil, err
 					}
-
-					specialInstructions := ""
-					if input["specialInstructions"] != nil {
-						specialInstructions = input["specialInstructions"].(string)
+					in, err := dossierConfigFromInput(p.Context, db, input, configID)
+					if err != nil {
+						return nil, err
 					}
-
-					// Convert feedUrls from []interface{} to []string
-					feedURLStrings := make([]string, len(feedUrls))
-					for i, url := range feedUrls {
-						feedURLStrings[i] = url.(string)
+					// NULL keeps the stored sandbox_sends (see COALESCE below)
+					var sandboxSends *int
+					if input["sandboxSends"] != nil {
+						sandboxSends = &in.SandboxSends
 					}
 
 					var config models.DossierConfig
-					err := db.QueryRowContext(p.Context, `
+					err = database.ScanConfig(db.QueryRowContext(p.Context, `
 						UPDATE dossier_configs 
 						SET title = $2, email = $3, feed_urls = $4, article_count = $5, 
 							frequency = $6, delivery_time = $7, timezone = $8, tone = $9, 
-							language = $10, special_instructions = $11, updated_at = CURRENT_TIMESTAMP
+							language = $10, special_instructions = $11, rollup_source_id = $12,
+							interests = $13, enforce_language = $14, include_executive_summary = $15,
+							include_conclusion = $16, skip_weekends = $17, skip_dates = $18,
+							feed_ids = $19, min_articles = $20, delivery_weekdays = $21,
+							max_per_source = $22, cta_label = $23, footer_text = $24,
+							articles_only_fallback = $25, order_by_importance = $26,
+							preserve_titles = $27, attach_pdf = $28,
+							sandbox_sends = COALESCE($29, sandbox_sends), pipeline = $30,
+							temperature = $31, use_feed_content = $32, split_by_category = $33,
+							recipient_name = $34, since_last_delivery = $35,
+							sponsored_filter = $36, sponsored_patterns = $37, distribution = $38,
+							tone_intensity = $39, candidate_pool_size = $40, updated_at = CURRENT_TIMESTAMP
 						WHERE id = $1
-						RETURNING id, title, email, feed_urls, article_count, frequency, 
-							delivery_time::text, timezone, tone, language, special_instructions, 
-							active, created_at
-					`, id, title, email, pq.Array(feedURLStrings), articleCount, frequency, deliveryTime,
-						timezone, tone, language, specialInstructions).Scan(
-
-						&config.ID, &config.Title, &config.Email, pq.Array(&config.FeedURLs),
-						&config.ArticleCount, &config.Frequency, &config.DeliveryTime,
-						&config.Timezone, &config.Tone, &config.Language,
-						&config.SpecialInstructions, &config.Active, &config.CreatedAt)
+						RETURNING `+database.ConfigColumns+`
+					`, configID, in.Title, in.Email, pq.Array(in.FeedURLs), in.ArticleCount, in.Frequency, in.DeliveryTime,
+						in.Timezone, in.Tone, in.Language, in.SpecialInstructions, in.RollupSourceID, in.Interests,
+						in.EnforceLanguage, in.IncludeExecutiveSummary, in.IncludeConclusion,
+						in.SkipWeekends, pq.Array(in.SkipDates), pq.Array(in.FeedIDs), in.MinArticles,
+						pq.Array(in.DeliveryWeekdays), in.MaxPerSource, in.CTALabel, in.FooterText,
+						in.ArticlesOnlyFallback, in.OrderByImportance, in.PreserveTitles,
+						in.AttachPDF, sandboxSends, in.Pipeline, in.Temperature,
+						in.UseFeedContent, in.SplitByCategory, in.RecipientName, in.SinceLastDelivery,
+						in.SponsoredFilter, pq.Array(in.SponsoredPatterns), in.Distribution, in.ToneIntensity,
+						in.CandidatePoolSize), &config)
 					if err != nil {
 						return nil, err
 					}
@@ -837,14 +2052,17 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//
 				// Warning: This is a hard delete, not soft delete. Cannot be undone.
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					id := p.Args["id"].(string)
+					id, err := idArg(p, "id")
+					if err != nil {
+						return false, err
+					}
 
-					_, err := db.ExecContext(p.Context, "DELETE FROM dossier_configs WHERE id = $1", id)
+					_, err = db.ExecContext(p.Context, "DELETE FROM dossier_configs WHERE id = $1", id)
 					if err != nil {
 						return false, err
 					}
 
-					log.Printf("Deleted dossier config ID: %s", id)
+					log.Printf("Deleted dossier config ID: %d", id)
 					return true, nil
 				},
 			},
@@ -854,6 +2072,10 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 					"configId": &graphql.ArgumentConfig{
 						Type: graphql.NewNonNull(graphql.ID),
 					},
+					"force": &graphql.ArgumentConfig{
+						Type:         graphql.Boolean,
+						DefaultValue: false,
+					},
 				},
 				// Manually generates and sends a dossier immediately.
 				//
@@ -866,6 +2088,7 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//
 				// Arguments:
 				//   - configId: Configuration ID to process (required)
+				//   - force: Regenerate even if an identical summary is cached (default false)
 				//
 				// Returns:
 				//   - true if entire pipeline succeeds
@@ -873,28 +2096,40 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//
 				// Error Conditions:
 				//   - Configuration not found or inactive
+				//   - Generation already in progress for this configuration
 				//   - No articles found from RSS feeds
-				//   - AI summary generation fails
+				//   - AI summary generation fails (unless articlesOnlyFallback is set,
+				//     in which case the articles are sent without a summary)
 				//   - Email delivery fails
 				//
+				// Failures after the in-progress check are recorded as failed
+				// deliveries, visible in dossiers with their error.
+				//
+				// Timeout:
+				// The pipeline runs under its own GENERATION_TIMEOUT deadline, like a
+				// scheduled run, rather than the request's context. A client that
+				// disconnects or an HTTP_WRITE_TIMEOUT that passes doesn't stop a run
+				// midway; a run that exceeds the budget fails with a TIMEOUT error.
+				//
+				// Note: This holds the HTTP request open for the whole pipeline
+				// (potentially many minutes). Prefer queueDossierGeneration.
+				//
 				// Use Cases:
 				//   - Testing configuration before enabling automation
 				//   - Manual on-demand dossier generation
 				//   - Debugging delivery issues
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					configId := p.Args["configId"].(string)
+					configID, err := idArg(p, "configId")
+					if err != nil {
+						return false, err
+					}
 
 					// Get dossier config
 					var config models.DossierConfig
-					err := db.QueryRowContext(p.Context, `
-						SELECT id, title, email, feed_urls, article_count, frequency, 
-							   delivery_time::text, timezone, tone, language, special_instructions, 
-							   active, created_at
+					err = database.ScanConfig(db.QueryRowContext(p.Context, `
+						SELECT `+database.ConfigColumns+`
 						FROM dossier_configs WHERE id = $1 AND active = true
-					`, configId).Scan(&config.ID, &config.Title, &config.Email, pq.Array(&config.FeedURLs),
-						&config.ArticleCount, &config.Frequency, &config.DeliveryTime,
-						&config.Timezone, &config.Tone, &config.Language,
-						&config.SpecialInstructions, &config.Active, &config.CreatedAt)
+					`, configID), &config)
 					if err != nil {
 						if err == sql.ErrNoRows {
 							return false, fmt.Errorf("dossier configuration not found or inactive")
@@ -902,38 +2137,145 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 						return false, err
 					}
 
-					// Fetch articles from RSS feeds
-					articles, err := rssService.FetchArticlesFromFeeds(p.Context, config.FeedURLs, config.ArticleCount)
-					if err != nil {
-						return false, fmt.Errorf("failed to fetch articles: %w", err)
+					// The scheduler's own pipeline (rollups, split categories, skip rules,
+					// min_articles, articles_only_fallback, delivery recording), so a
+					// manual run cannot drift from a scheduled one. It rejects a config
+					// already in flight (double clicks, retries, or a scheduled run) to
+					// avoid duplicate emails, and runs under GENERATION_TIMEOUT rather
+					// than the request's context
+					force, _ := p.Args["force"].(bool)
+					if err := schedulerService.GenerateNow(config, force); err != nil {
+						if errors.Is(err, context.DeadlineExceeded) {
+							return false, fmt.Errorf("generation did not finish within its %s budget (GENERATION_TIMEOUT): %w",
+								schedulerService.GenerationTimeout(), err)
+						}
+						return false, err
 					}
 
-					if len(articles) == 0 {
-						return false, fmt.Errorf("no articles found from the configured feeds")
+					log.Printf("Successfully generated and sent dossier '%s' to %s", config.Title, config.Email)
+					return true, nil
+				},
+			},
+			"queueDossierGeneration": &graphql.Field{
+				Type: generationJobType,
+				Args: graphql.FieldConfigArgument{
+					"configId": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.ID),
+					},
+					"force": &graphql.ArgumentConfig{
+						Type:         graphql.Boolean,
+						DefaultValue: false,
+					},
+				},
+				// Queues generation and delivery of a dossier without waiting for it.
+				//
+				// Unlike generateAndSendDossier, this returns as soon as the job is
+				// queued, so long LLM work never holds the HTTP request open. Poll
+				// generationJob(id) for progress and the final result.
+				//
+				// Arguments:
+				//   - configId: Configuration ID to process (required)
+				//   - force: Regenerate even if an identical summary is cached (default false)
+				//
+				// Returns:
+				//   - GenerationJob in queued state
+				//
+				// Error Conditions:
+				//   - Configuration not found or inactive
+				//   - Generation already in progress for this configuration
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					configID, err := idArg(p, "configId")
+					if err != nil {
+						return nil, err
 					}
 
-					// Generate AI summary
-					summary, err := aiService.GenerateSummary(p.Context, articles, config.Tone, config.Language, config.SpecialInstructions)
+					var config models.DossierConfig
+					err = database.ScanConfig(db.QueryRowContext(p.Context, `
+						SELECT `+database.ConfigColumns+`
+						FROM dossier_configs WHERE id = $1 AND active = true
+					`, configID), &config)
 					if err != nil {
-						return false, fmt.Errorf("failed to generate summary: %w", err)
+						if err == sql.ErrNoRows {
+							return nil, fmt.Errorf("dossier configuration not found or inactive")
+						}
+						return nil, err
 					}
 
-					// Send email
-					err = emailService.SendDossier(&config, summary, articles)
+					force, _ := p.Args["force"].(bool)
+					job, err := schedulerService.EnqueueGeneration(config, force)
 					if err != nil {
-						return false, fmt.Errorf("failed to send dossier email: %w", err)
+						return nil, err
+					}
+					return generationJobToMap(job), nil
+				},
+			},
+			"generateAllActive": &graphql.Field{
+				Type: generationTriggerSummaryType,
+				// Queues generation for every active dossier configuration at once.
+				//
+				// Generation runs asynchronously through the scheduler's dispatcher,
+				// so this returns immediately with counts rather than waiting for
+				// emails to be sent. The scheduler's concurrency limit applies, and
+				// configurations that already have a generation in flight (for
+				// example a scheduled run in progress) are skipped.
+				//
+				// Returns:
+				//   - GenerationTriggerSummary with total/triggered/skipped counts
+				//   - error if the caller is not an admin or the query fails
+				//
+				// Use Cases:
+				//   - Validating changes after a model upgrade
+				//   - Smoke-testing all configurations after editing them
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := requireAdmin(p.Context); err != nil {
+						return nil, err
 					}
 
-					// Record delivery in database
-					_, err = db.ExecContext(p.Context, `
-						INSERT INTO dossier_deliveries (config_id, delivery_date, summary, article_count, email_sent)
-						VALUES ($1, CURRENT_TIMESTAMP, $2, $3, true)
-					`, config.ID, summary, len(articles))
+					summary, err := schedulerService.GenerateAllActive()
 					if err != nil {
-						log.Printf("Failed to record dossier delivery: %v", err)
+						return nil, fmt.Errorf("failed to trigger generation: %w", err)
 					}
 
-					log.Printf("Successfully generated and sent dossier '%s' to %s", config.Title, config.Email)
+					return map[string]interface{}{
+						"total":     summary.Total,
+						"triggered": summary.Triggered,
+						"skipped":   summary.Skipped,
+					}, nil
+				},
+			},
+			"resendDelivery": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"deliveryId": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.ID),
+					},
+				},
+				// Sends a failed delivery's stored dossier again.
+				//
+				// When a summary was generated but the email failed (SMTP down,
+				// bad credentials), the failed delivery keeps the summary and
+				// articles. This re-sends them without fetching feeds or running
+				// the model, and marks the delivery sent on success.
+				//
+				// Arguments:
+				//   - deliveryId: Dossier (delivery) ID with resendable true
+				//
+				// Returns:
+				//   - true once the email is sent
+				//
+				// Error Conditions:
+				//   - Delivery not found
+				//   - Delivery already sent or has nothing stored (NOT_RESENDABLE)
+				//   - Configuration deleted or inactive
+				//   - Email delivery fails again (the delivery stays resendable)
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					deliveryID, err := idArg(p, "deliveryId")
+					if err != nil {
+						return false, err
+					}
+					if err := schedulerService.ResendDelivery(p.Context, deliveryID); err != nil {
+						return false, err
+					}
 					return true, nil
 				},
 			},
@@ -971,19 +2313,17 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//
 				// Note: Does not record delivery in dossier_deliveries table.
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					configId := p.Args["configId"].(string)
+					configID, err := idArg(p, "configId")
+					if err != nil {
+						return false, err
+					}
 
 					// Get dossier config
 					var config models.DossierConfig
-					err := db.QueryRowContext(p.Context, `
-						SELECT id, title, email, feed_urls, article_count, frequency, 
-							   delivery_time::text, timezone, tone, language, special_instructions, 
-							   active, created_at
+					err = database.ScanConfig(db.QueryRowContext(p.Context, `
+						SELECT `+database.ConfigColumns+`
 						FROM dossier_configs WHERE id = $1
-					`, configId).Scan(&config.ID, &config.Title, &config.Email, pq.Array(&config.FeedURLs),
-						&config.ArticleCount, &config.Frequency, &config.DeliveryTime,
-						&config.Timezone, &config.Tone, &config.Language,
-						&config.SpecialInstructions, &config.Active, &config.CreatedAt)
+					`, configID), &config)
 					if err != nil {
 						if err == sql.ErrNoRows {
 							return false, fmt.Errorf("dossier configuration not found")
@@ -991,66 +2331,14 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 						return false, err
 					}
 
-					testContent := `This is a test email from your Dossier system.
-
-**Configuration Details:**
-- Title: ` + config.Title + `
-- Frequency: ` + config.Frequency + `
-- Delivery Time: ` + config.DeliveryTime + ` (` + config.Timezone + `)
-- Article Count: ` + fmt.Sprintf("%d", config.ArticleCount) + `
-- AI Tone: ` + config.Tone + `
-- Language: ` + config.Language + `
-
-**RSS Feeds:**`
-
-					for i, feedURL := range config.FeedURLs {
-						testContent += fmt.Sprintf("\n%d. %s", i+1, feedURL)
-					}
-
-					testContent += `
-
-**Sample Articles:** _(This is test data)_
-1. **Breaking News: Technology Advances Continue** - Lorem ipsum dolor sit amet, consectetur adipiscing elit.
-2. **Market Update: Economic Trends Show Growth** - Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.
-3. **Innovation Spotlight: New Developments** - Ut enim ad minim veniam, quis nostrud exercitation ullamco.
-
----
-*This was a test email sent at ` + time.Now().Format("2006-01-02 15:04:05 MST") + `*
-*Your actual dossiers will contain real articles from your configured RSS feeds.*`
-
-					// Modify config title to indicate test email
-					testConfig := config
-					testConfig.Title = config.Title + " - Test Email"
-
-					// Create sample articles for email template rendering
-					sampleArticles := []models.Article{
-						{
-							ID:          1,
-							Title:       "Breaking News: Technology Advances Continue",
-							Link:        "https://example.com/article1",
-							Description: "Lorem ipsum dolor sit amet, consectetur adipiscing elit.",
-							Author:      "Test Author",
-							PublishedAt: time.Now(),
-						},
-						{
-							ID:          2,
-							Title:       "Market Update: Economic Trends Show Growth",
-							Link:        "https://example.com/article2",
-							Description: "Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.",
-							Author:      "Test Reporter",
-							PublishedAt: time.Now().Add(-1 * time.Hour),
-						},
-						{
-							ID:          3,
-							Title:       "Innovation Spotlight: New Developments",
-							Link:        "https://example.com/article3",
-							Description: "Ut enim ad minim veniam, quis nostrud exercitation ullamco.",
-							Author:      "Tech Writer",
-							PublishedAt: time.Now().Add(-2 * time.Hour),
-						},
-					}
-
-					err = emailService.SendDossier(&testConfig, testContent, sampleArticles)
+					feedURLs, err := database.ResolveFeedURLs(p.Context, db, &config)
+					if err != nil {
+						return false, err
+					}
+
+					testConfig, testContent, sampleArticles := email.TestDossier(&config, feedURLs, time.Now())
+
+					err = emailService.SendDossier(p.Context, testConfig, testContent, sampleArticles)
 					if err != nil {
 						return false, fmt.Errorf("failed to send test email: %w", err)
 					}
@@ -1113,14 +2401,21 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//   - Custom tone for specific audience
 				//   - Experimental tone variations
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					input := p.Args["input"].(map[string]interface{})
-					var tone models.Tone
+					input, err := inputArg(p, "input")
+					if err != nil {
+						return nil, err
+					}
+					name, prompt, err := toneFromInput(input)
+					if err != nil {
+						return nil, err
+					}
 
-					err := db.QueryRowContext(p.Context, `
+					var tone models.Tone
+					err = db.QueryRowContext(p.Context, `
 						INSERT INTO tones (name, prompt) 
 						VALUES ($1, $2) 
 						RETURNING id, name, prompt, is_system_default, created_at, updated_at
-					`, input["name"], input["prompt"]).Scan(
+					`, name, prompt).Scan(
 						&tone.ID, &tone.Name, &tone.Prompt, &tone.IsSystemDefault, &tone.CreatedAt, &tone.UpdatedAt)
 					if err != nil {
 						return nil, err
@@ -1155,16 +2450,26 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//
 				// Protection: System default tones cannot be modified.
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					id := p.Args["id"].(int)
-					input := p.Args["input"].(map[string]interface{})
-					var tone models.Tone
+					id, err := intArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
+					input, err := inputArg(p, "input")
+					if err != nil {
+						return nil, err
+					}
+					name, prompt, err := toneFromInput(input)
+					if err != nil {
+						return nil, err
+					}
 
-					err := db.QueryRowContext(p.Context, `
+					var tone models.Tone
+					err = db.QueryRowContext(p.Context, `
 						UPDATE tones 
 						SET name = $1, prompt = $2, updated_at = CURRENT_TIMESTAMP 
 						WHERE id = $3 AND is_system_default = false
 						RETURNING id, name, prompt, is_system_default, created_at, updated_at
-					`, input["name"], input["prompt"], id).Scan(
+					`, name, prompt, id).Scan(
 						&tone.ID, &tone.Name, &tone.Prompt, &tone.IsSystemDefault, &tone.CreatedAt, &tone.UpdatedAt)
 					if err != nil {
 						return nil, err
@@ -1195,10 +2500,14 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 				//
 				// Protection: System default tones cannot be deleted.
 				//
-				// Note: Dossier configurations using this tone should be updated
-				// before deletion to avoid reference errors.
+				// Note: Dossier configurations using this tone (see Tone.configs)
+				// should be updated before deletion; otherwise they fall back to
+				// DEFAULT_TONE_PROMPT, which configToneStatus reports.
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					id := p.Args["id"].(int)
+					id, err := intArg(p, "id")
+					if err != nil {
+						return false, err
+					}
 
 					result, err := db.ExecContext(p.Context, `
 						DELETE FROM tones WHERE id = $1 AND is_system_default = false
@@ -1215,6 +2524,146 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 					return rowsAffected > 0, nil
 				},
 			},
+			"createFeed": &graphql.Field{
+				Type: feedType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(feedInputType),
+					},
+				},
+				// Creates a shared feed that configurations can reference by ID.
+				//
+				// Arguments:
+				//   - input: FeedInput with URL and optional metadata
+				//
+				// Returns:
+				//   - Newly created Feed object with generated ID
+				//   - error for duplicate URLs or database issues
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					input, err := inputArg(p, "input")
+					if err != nil {
+						return nil, err
+					}
+					in, err := feedFromInput(input)
+					if err != nil {
+						return nil, err
+					}
+
+					var feed models.Feed
+					err = database.ScanFeed(db.QueryRowContext(p.Context, `
+						INSERT INTO feeds (url, title, description, category, priority, active)
+						VALUES ($1, $2, $3, $4, $5, $6)
+						RETURNING `+database.FeedColumns+`
+					`, in.URL, in.Title, in.Description, in.Category, in.Priority, in.Active), &feed)
+					if err != nil {
+						return nil, err
+					}
+
+					log.Printf("Created feed %d: %s", feed.ID, feed.URL)
+					return feed, nil
+				},
+			},
+			"updateFeed": &graphql.Field{
+				Type: feedType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+					"input": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(feedInputType),
+					},
+				},
+				// Updates a shared feed. Changes apply to every configuration
+				// that references it.
+				//
+				// Arguments:
+				//   - id: Feed ID to update (required)
+				//   - input: FeedInput with the full set of values
+				//
+				// Returns:
+				//   - Updated Feed object
+				//   - error if ID doesn't exist or database issue
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := intArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
+					input, err := inputArg(p, "input")
+					if err != nil {
+						return nil, err
+					}
+					in, err := feedFromInput(input)
+					if err != nil {
+						return nil, err
+					}
+
+					var feed models.Feed
+					err = database.ScanFeed(db.QueryRowContext(p.Context, `
+						UPDATE feeds
+						SET url = $2, title = $3, description = $4, category = $5, priority = $6,
+							active = $7, updated_at = CURRENT_TIMESTAMP
+						WHERE id = $1
+						RETURNING `+database.FeedColumns+`
+					`, id, in.URL, in.Title, in.Description, in.Category, in.Priority, in.Active), &feed)
+					if err != nil {
+						return nil, err
+					}
+					return feed, nil
+				},
+			},
+			"deleteFeed": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+				},
+				// Deletes a shared feed.
+				//
+				// Behavior:
+				//   - Removes the feed ID from every configuration's feedIds in the
+				//     same transaction, so no config is left with a dangling reference
+				//
+				// Returns:
+				//   - true if the feed was deleted
+				//   - false if it doesn't exist
+				//   - error for database issues
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := intArg(p, "id")
+					if err != nil {
+						return false, err
+					}
+
+					tx, err := db.BeginTx(p.Context, nil)
+					if err != nil {
+						return false, err
+					}
+					defer tx.Rollback()
+
+					_, err = tx.ExecContext(p.Context, `
+						UPDATE dossier_configs SET feed_ids = array_remove(feed_ids, $1)
+						WHERE $1 = ANY(feed_ids)
+					`, id)
+					if err != nil {
+						return false, err
+					}
+
+					result, err := tx.ExecContext(p.Context, `DELETE FROM feeds WHERE id = $1`, id)
+					if err != nil {
+						return false, err
+					}
+
+					rowsAffected, err := result.RowsAffected()
+					if err != nil {
+						return false, err
+					}
+
+					if err := tx.Commit(); err != nil {
+						return false, err
+					}
+					return rowsAffected > 0, nil
+				},
+			},
 		},
 	})
 
@@ -1248,6 +2697,7 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	// Configuration:
 	//   - Pretty: Formats JSON responses for readability (should be false in production)
 	//   - GraphiQL: Enables interactive GraphQL IDE at same endpoint
+	//   - FormatErrorFn: Adds stable extensions.code values for known failures
 	//
 	// GraphiQL IDE:
 	//   - Access at http://localhost:8080/graphql in browser
@@ -1258,10 +2708,198 @@ func Handler(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailSe
 	//   - Set Pretty: false to reduce bandwidth
 	//   - Set GraphiQL: false to disable IDE in production
 	h := handler.New(&handler.Config{
-		Schema:   &schema,
-		Pretty:   true,
-		GraphiQL: true,
+		Schema:        &schema,
+		Pretty:        true,
+		GraphiQL:      true,
+		FormatErrorFn: formatError,
 	})
 
 	return h, nil
 }
+
+// insertDossierConfig stores a validated configuration and returns the
+// created row. Shared by createDossierConfig and importDossierConfig.
+//
+// Parameters:
+//   - ctx: Request context
+//   - db: Database connection
+//   - in: Configuration from dossierConfigFromInput
+//
+// Returns:
+//   - *models.DossierConfig: Created configuration with its generated ID
+//   - error: Database error
+func insertDossierConfig(ctx context.Context, db *sql.DB, in *models.DossierConfig) (*models.DossierConfig, error) {
+	var config models.DossierConfig
+	err := database.ScanConfig(db.QueryRowContext(ctx, `
+		INSERT INTO dossier_configs (title, email, feed_urls, article_count, frequency, 
+			delivery_time, timezone, tone, language, special_instructions, rollup_source_id,
+			interests, enforce_language, include_executive_summary,
+			include_conclusion, skip_weekends, skip_dates, feed_ids,
+			min_articles, delivery_weekdays, max_per_source, cta_label, footer_text,
+			articles_only_fallback, order_by_importance, preserve_titles,
+			attach_pdf, sandbox_sends, pipeline, temperature,
+			use_feed_content, split_by_category, recipient_name, since_last_delivery,
+			sponsored_filter, sponsored_patterns, distribution, tone_intensity, candidate_pool_size)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39)
+		RETURNING `+database.ConfigColumns+`
+	`, in.Title, in.Email, pq.Array(in.FeedURLs), in.ArticleCount, in.Frequency, in.DeliveryTime,
+		in.Timezone, in.Tone, in.Language, in.SpecialInstructions, in.RollupSourceID, in.Interests,
+		in.EnforceLanguage, in.IncludeExecutiveSummary, in.IncludeConclusion,
+		in.SkipWeekends, pq.Array(in.SkipDates), pq.Array(in.FeedIDs), in.MinArticles,
+		pq.Array(in.DeliveryWeekdays), in.MaxPerSource, in.CTALabel, in.FooterText,
+		in.ArticlesOnlyFallback, in.OrderByImportance, in.PreserveTitles,
+		in.AttachPDF, in.SandboxSends, in.Pipeline, in.Temperature,
+		in.UseFeedContent, in.SplitByCategory, in.RecipientName, in.SinceLastDelivery,
+		in.SponsoredFilter, pq.Array(in.SponsoredPatterns), in.Distribution, in.ToneIntensity,
+		in.CandidatePoolSize), &config)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// configsUsingTone lists the configurations whose tone is the given name.
+//
+// Parameters:
+//   - ctx: Request context
+//   - db: Database connection
+//   - name: Tone name
+//
+// Returns:
+//   - []map[string]interface{}: ConfigReference values ordered by title
+//   - error: Database error
+func configsUsingTone(ctx context.Context, db *sql.DB, name string) ([]map[string]interface{}, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, title, active FROM dossier_configs
+		WHERE tone = $1
+		ORDER BY title, id
+	`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	configs := []map[string]interface{}{}
+	for rows.Next() {
+		var id int
+		var title string
+		var active bool
+		if err := rows.Scan(&id, &title, &active); err != nil {
+			return nil, err
+		}
+		configs = append(configs, map[string]interface{}{
+			"id":     strconv.Itoa(id),
+			"title":  title,
+			"active": active,
+		})
+	}
+	return configs, rows.Err()
+}
+
+// generationJobToMap converts a scheduler job into the GraphQL response shape,
+// formatting timestamps as RFC3339 and omitting unset ones.
+func generationJobToMap(job scheduler.GenerationJob) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":        job.ID,
+		"configId":  strconv.Itoa(job.ConfigID),
+		"status":    string(job.Status),
+		"createdAt": job.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if job.Error != "" {
+		result["error"] = job.Error
+		if code := errorCode(job.Err); code != "" {
+			result["errorCode"] = code
+		}
+	}
+	if job.StartedAt != nil {
+		result["startedAt"] = job.StartedAt.UTC().Format(time.RFC3339)
+	}
+	if job.FinishedAt != nil {
+		result["finishedAt"] = job.FinishedAt.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// ============================================================================
+// ADMIN AUTHORIZATION
+// ============================================================================
+
+// contextKey is a private type for request context values set by this package.
+type contextKey string
+
+// adminContextKey marks requests that passed the admin token check.
+const adminContextKey contextKey = "admin"
+
+// AdminMiddleware flags requests that are authorized for admin-only operations.
+//
+// Dossier is single-user and unauthenticated by design, so admin gating is
+// opt-in: when ADMIN_TOKEN is unset every request is treated as admin. When it
+// is set, requests must send "Authorization: Bearer <ADMIN_TOKEN>" to use
+// admin-only mutations such as generateAllActive. Other operations are unaffected.
+// HTTP Basic auth with the token as the password (any username) is accepted
+// too, so browsers can open admin routes such as /preview.
+//
+// Parameters:
+//   - next: Handler to wrap (typically the GraphQL handler)
+//
+// Returns:
+//   - http.Handler: Handler that annotates the request context
+//
+// Example:
+//
+//	r.Handle("/graphql", graphql.AdminMiddleware(gqlHandler))
+func AdminMiddleware(next http.Handler) http.Handler {
+	token := os.Getenv("ADMIN_TOKEN")
+	if token == "" {
+		log.Println("ADMIN_TOKEN not set, admin-only operations are open to all clients")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isAdmin := token == "" ||
+			subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) == 1
+		if !isAdmin {
+			if _, password, ok := r.BasicAuth(); ok {
+				isAdmin = subtle.ConstantTimeCompare([]byte(password), []byte(token)) == 1
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), adminContextKey, isAdmin)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// serverSettingFields converts a component's reported setting into a
+// ServerSetting result. This is where the environment is consulted: fromEnv
+// reports whether the variable was set, and a secret's value is replaced by
+// whether it is set, so it never leaves the server.
+//
+// Parameters:
+//   - group: Component that reported the setting
+//   - setting: Effective setting, as returned by the component's Settings
+//
+// Returns:
+//   - map[string]interface{}: ServerSetting fields
+func serverSettingFields(group string, setting models.Setting) map[string]interface{} {
+	fromEnv := os.Getenv(setting.Name) != ""
+	value := setting.Value
+	if setting.Secret {
+		value = "(not set)"
+		if fromEnv {
+			value = "(set)"
+		}
+	}
+	return map[string]interface{}{
+		"group":   group,
+		"name":    setting.Name,
+		"value":   value,
+		"fromEnv": fromEnv,
+	}
+}
+
+// requireAdmin returns an error unless the request was authorized by AdminMiddleware.
+func requireAdmin(ctx context.Context) error {
+	if isAdmin, _ := ctx.Value(adminContextKey).(bool); !isAdmin {
+		return fmt.Errorf("admin authorization required")
+	}
+	return nil
+}
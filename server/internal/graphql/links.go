@@ -0,0 +1,240 @@
+package graphql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/geraldfingburke/dossier/server/internal/database"
+	"github.com/geraldfingburke/dossier/server/internal/email"
+	"github.com/geraldfingburke/dossier/server/internal/models"
+)
+
+// ============================================================================
+// SIGNED EMAIL LINKS
+// ============================================================================
+
+// linkPage is the data for linkPageTemplate.
+type linkPage struct {
+	Heading string
+	Message string
+	Details []string     // Lines describing the configuration
+	Buttons []linkButton // POST forms back to the same signed URL
+}
+
+// linkButton is one form on a link page. Value is sent as the "do" field.
+type linkButton struct {
+	Label string
+	Value string
+}
+
+// linkPageTemplate renders the small standalone pages behind email links.
+var linkPageTemplate = template.Must(template.New("link").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Dossier - {{.Heading}}</title>
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; max-width: 560px; margin: 40px auto; padding: 0 20px; color: #333; line-height: 1.6;">
+    <h1>{{.Heading}}</h1>
+    {{if .Message}}<p>{{.Message}}</p>{{end}}
+    {{if .Details}}<ul>{{range .Details}}<li>{{.}}</li>{{end}}</ul>{{end}}
+    {{range .Buttons}}
+    <form method="post">
+        <input type="hidden" name="do" value="{{.Value}}">
+        <button type="submit" style="background: #667eea; color: white; border: 0; padding: 10px 20px; border-radius: 5px; font-size: 1em;">{{.Label}}</button>
+    </form>
+    {{end}}
+</body>
+</html>`))
+
+// UnsubscribeHandler serves /unsubscribe?token=..., the signed unsubscribe
+// link in every dossier email. It needs no login: the token (see
+// email.Service.SignLink) proves the link came from an email for that
+// configuration.
+//
+// GET shows a confirmation page, since mail scanners and link previews fetch
+// links without a person clicking. POST deactivates the configuration; this
+// is also what mail clients send for RFC 8058 one-click unsubscribe from the
+// List-Unsubscribe header.
+//
+// Parameters:
+//   - db: Database connection
+//   - emailService: Verifies link tokens
+//
+// Returns:
+//   - http.Handler: Handler to mount at /unsubscribe, outside AdminMiddleware
+func UnsubscribeHandler(db *sql.DB, emailService *email.Service) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		config, ok := linkConfig(w, r, db, emailService, email.LinkUnsubscribe)
+		if !ok {
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			if !setConfigActive(w, r, db, config, false) {
+				return
+			}
+			renderLinkPage(w, http.StatusOK, linkPage{
+				Heading: "Unsubscribed",
+				Message: fmt.Sprintf("%s will no longer receive %q. You can resume it any time from Dossier.",
+					config.Email, config.Title),
+			})
+			return
+		}
+
+		if !config.Active {
+			renderLinkPage(w, http.StatusOK, linkPage{
+				Heading: "Already unsubscribed",
+				Message: fmt.Sprintf("%q is not being delivered to %s.", config.Title, config.Email),
+			})
+			return
+		}
+		renderLinkPage(w, http.StatusOK, linkPage{
+			Heading: "Unsubscribe?",
+			Message: fmt.Sprintf("%s will stop receiving %q.", config.Email, config.Title),
+			Buttons: []linkButton{{Label: "Unsubscribe", Value: "unsubscribe"}},
+		})
+	})
+}
+
+// ManageHandler serves /manage?token=..., the signed "Manage delivery" link
+// in every dossier email. Like UnsubscribeHandler it needs no login. GET shows
+// the configuration's schedule and status; POST with do=pause or do=resume
+// deactivates or reactivates it.
+//
+// Parameters:
+//   - db: Database connection
+//   - emailService: Verifies link tokens
+//
+// Returns:
+//   - http.Handler: Handler to mount at /manage, outside AdminMiddleware
+func ManageHandler(db *sql.DB, emailService *email.Service) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		config, ok := linkConfig(w, r, db, emailService, email.LinkManage)
+		if !ok {
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			switch r.PostFormValue("do") {
+			case "pause":
+				ok = setConfigActive(w, r, db, config, false)
+			case "resume":
+				ok = setConfigActive(w, r, db, config, true)
+			default:
+				renderLinkPage(w, http.StatusBadRequest, linkPage{Heading: "Unknown action"})
+				return
+			}
+			if !ok {
+				return
+			}
+		}
+
+		status, button := "Active", linkButton{Label: "Pause deliveries", Value: "pause"}
+		if !config.Active {
+			status, button = "Paused", linkButton{Label: "Resume deliveries", Value: "resume"}
+		}
+		renderLinkPage(w, http.StatusOK, linkPage{
+			Heading: config.Title,
+			Details: []string{
+				"Recipient: " + config.Email,
+				fmt.Sprintf("Schedule: %s at %s (%s)", config.Frequency, config.DeliveryTime, config.Timezone),
+				fmt.Sprintf("Articles: up to %d", config.ArticleCount),
+				"Status: " + status,
+			},
+			Buttons: []linkButton{button},
+		})
+	})
+}
+
+// linkConfig verifies a request's signed token for action and loads the
+// configuration it names. On failure the error page has already been written.
+//
+// Parameters:
+//   - w: Response writer for error pages
+//   - r: Request carrying ?token=
+//   - db: Database connection
+//   - emailService: Verifies the token
+//   - action: Action the handler performs (email.LinkUnsubscribe, email.LinkManage)
+//
+// Returns:
+//   - *models.DossierConfig: Configuration the token authorizes
+//   - bool: false if a response was already written
+func linkConfig(w http.ResponseWriter, r *http.Request, db *sql.DB, emailService *email.Service, action string) (*models.DossierConfig, bool) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	configID, err := emailService.VerifyLink(r.URL.Query().Get("token"), action, time.Now())
+	if errors.Is(err, email.ErrLinkExpired) {
+		renderLinkPage(w, http.StatusGone, linkPage{
+			Heading: "Link expired",
+			Message: "This link is no longer valid. Use the link in a more recent dossier.",
+		})
+		return nil, false
+	}
+	if err != nil {
+		renderLinkPage(w, http.StatusForbidden, linkPage{
+			Heading: "Invalid link",
+			Message: "This link is invalid. Make sure you opened the whole link from your email.",
+		})
+		return nil, false
+	}
+
+	var config models.DossierConfig
+	err = database.ScanConfig(db.QueryRowContext(r.Context(), `
+		SELECT `+database.ConfigColumns+`
+		FROM dossier_configs WHERE id = $1
+	`, configID), &config)
+	if err == sql.ErrNoRows {
+		renderLinkPage(w, http.StatusNotFound, linkPage{
+			Heading: "Dossier not found",
+			Message: "This dossier no longer exists, so nothing more will be sent.",
+		})
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("Email link: failed to load config %d: %v", configID, err)
+		http.Error(w, "failed to load configuration", http.StatusInternalServerError)
+		return nil, false
+	}
+	return &config, true
+}
+
+// setConfigActive activates or deactivates a configuration from an email
+// link, updating config in place. On failure the error has been written.
+//
+// Returns:
+//   - bool: false if a response was already written
+func setConfigActive(w http.ResponseWriter, r *http.Request, db *sql.DB, config *models.DossierConfig, active bool) bool {
+	_, err := db.ExecContext(r.Context(), `
+		UPDATE dossier_configs SET active = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1
+	`, config.ID, active)
+	if err != nil {
+		log.Printf("Email link: failed to update config %d: %v", config.ID, err)
+		http.Error(w, "failed to update configuration", http.StatusInternalServerError)
+		return false
+	}
+
+	config.Active = active
+	log.Printf("Email link: config %d (%s) set active=%t", config.ID, config.Title, active)
+	return true
+}
+
+// renderLinkPage writes a link page with the given status code.
+func renderLinkPage(w http.ResponseWriter, status int, page linkPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := linkPageTemplate.Execute(w, page); err != nil {
+		log.Printf("Email link: failed to render page: %v", err)
+	}
+}
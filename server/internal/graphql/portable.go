@@ -0,0 +1,347 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/geraldfingburke/dossier/server/internal/database"
+	"github.com/geraldfingburke/dossier/server/internal/models"
+)
+
+// ============================================================================
+// CONFIG EXPORT / IMPORT
+// ============================================================================
+
+// portableConfigVersion identifies the export document format. Imports of any
+// other version are rejected rather than half-applied.
+const portableConfigVersion = 1
+
+// portableConfig is the JSON document produced by exportDossierConfig and
+// accepted by importDossierConfig.
+//
+// Keys match DossierConfigInput so a document can be edited by hand. Values
+// that only make sense on the exporting server are replaced by portable ones:
+//   - feedIds: the referenced feeds' URLs are folded into feedUrls
+//   - tone: exported by name, with tonePrompt carrying a custom tone's prompt
+//     so it can be recreated on an instance that lacks it
+//   - rollupSourceId: exported as rollupSource, the source config's title
+type portableConfig struct {
+	Version                 int      `json:"version"`
+	Title                   string   `json:"title"`
+	Email                   string   `json:"email"`
+	FeedURLs                []string `json:"feedUrls"`
+	ArticleCount            int      `json:"articleCount"`
+	Frequency               string   `json:"frequency"`
+	DeliveryTime            string   `json:"deliveryTime"`
+	Timezone                string   `json:"timezone"`
+	Tone                    string   `json:"tone"`
+	TonePrompt              string   `json:"tonePrompt,omitempty"`
+	Language                string   `json:"language"`
+	SpecialInstructions     string   `json:"specialInstructions"`
+	Interests               string   `json:"interests"`
+	EnforceLanguage         bool     `json:"enforceLanguage"`
+	IncludeExecutiveSummary bool     `json:"includeExecutiveSummary"`
+	IncludeConclusion       bool     `json:"includeConclusion"`
+	SkipWeekends            bool     `json:"skipWeekends"`
+	SkipDates               []string `json:"skipDates"`
+	MinArticles             int      `json:"minArticles"`
+	DeliveryWeekdays        []int    `json:"deliveryWeekdays"`
+	MaxPerSource            int      `json:"maxPerSource"`
+	CTALabel                string   `json:"ctaLabel"`
+	FooterText              string   `json:"footerText"`
+	ArticlesOnlyFallback    bool     `json:"articlesOnlyFallback"`
+	OrderByImportance       bool     `json:"orderByImportance"`
+	PreserveTitles          bool     `json:"preserveTitles"`
+	AttachPDF               bool     `json:"attachPdf"`
+	Pipeline                string   `json:"pipeline"`
+	Temperature             *float64 `json:"temperature,omitempty"`
+	UseFeedContent          bool     `json:"useFeedContent"`
+	SplitByCategory         bool     `json:"splitByCategory"`
+	RecipientName           string   `json:"recipientName,omitempty"`
+	SinceLastDelivery       bool     `json:"sinceLastDelivery"`
+	SponsoredFilter         string   `json:"sponsoredFilter"`
+	SponsoredPatterns       []string `json:"sponsoredPatterns,omitempty"`
+	Distribution            string   `json:"distribution,omitempty"`
+	ToneIntensity           string   `json:"toneIntensity,omitempty"`
+	CandidatePoolSize       int      `json:"candidatePoolSize,omitempty"`
+	RollupSource            string   `json:"rollupSource,omitempty"`
+}
+
+// input converts the document into DossierConfigInput arguments so imports
+// go through the same validation as createDossierConfig.
+func (doc *portableConfig) input() map[string]interface{} {
+	feedURLs := make([]interface{}, len(doc.FeedURLs))
+	for i, feedURL := range doc.FeedURLs {
+		feedURLs[i] = feedURL
+	}
+	skipDates := make([]interface{}, len(doc.SkipDates))
+	for i, date := range doc.SkipDates {
+		skipDates[i] = date
+	}
+	weekdays := make([]interface{}, len(doc.DeliveryWeekdays))
+	for i, day := range doc.DeliveryWeekdays {
+		weekdays[i] = day
+	}
+	sponsoredPatterns := make([]interface{}, len(doc.SponsoredPatterns))
+	for i, pattern := range doc.SponsoredPatterns {
+		sponsoredPatterns[i] = pattern
+	}
+
+	input := map[string]interface{}{
+		"title":                   doc.Title,
+		"email":                   doc.Email,
+		"feedUrls":                feedURLs,
+		"articleCount":            doc.ArticleCount,
+		"frequency":               doc.Frequency,
+		"deliveryTime":            doc.DeliveryTime,
+		"timezone":                doc.Timezone,
+		"tone":                    doc.Tone,
+		"language":                doc.Language,
+		"specialInstructions":     doc.SpecialInstructions,
+		"interests":               doc.Interests,
+		"enforceLanguage":         doc.EnforceLanguage,
+		"includeExecutiveSummary": doc.IncludeExecutiveSummary,
+		"includeConclusion":       doc.IncludeConclusion,
+		"skipWeekends":            doc.SkipWeekends,
+		"skipDates":               skipDates,
+		"minArticles":             doc.MinArticles,
+		"deliveryWeekdays":        weekdays,
+		"maxPerSource":            doc.MaxPerSource,
+		"ctaLabel":                doc.CTALabel,
+		"footerText":              doc.FooterText,
+		"articlesOnlyFallback":    doc.ArticlesOnlyFallback,
+		"orderByImportance":       doc.OrderByImportance,
+		"preserveTitles":          doc.PreserveTitles,
+		"attachPdf":               doc.AttachPDF,
+		"pipeline":                doc.Pipeline,
+		"useFeedContent":          doc.UseFeedContent,
+		"splitByCategory":         doc.SplitByCategory,
+		"recipientName":           doc.RecipientName,
+		"sinceLastDelivery":       doc.SinceLastDelivery,
+		"sponsoredPatterns":       sponsoredPatterns,
+	}
+	// Documents exported before sponsored filtering existed get the default
+	if doc.SponsoredFilter != "" {
+		input["sponsoredFilter"] = doc.SponsoredFilter
+	}
+	if doc.Distribution != "" {
+		input["distribution"] = doc.Distribution
+	}
+	if doc.ToneIntensity != "" {
+		input["toneIntensity"] = doc.ToneIntensity
+	}
+	if doc.CandidatePoolSize != 0 {
+		input["candidatePoolSize"] = doc.CandidatePoolSize
+	}
+	// Absent means "server default"; a typed nil would look like a value
+	if doc.Temperature != nil {
+		input["temperature"] = *doc.Temperature
+	}
+	return input
+}
+
+// exportDossierConfig renders a configuration as a portable JSON document.
+//
+// Parameters:
+//   - ctx: Request context
+//   - db: Database connection
+//   - id: Configuration to export
+//
+// Returns:
+//   - string: Indented JSON document ("" when the config does not exist)
+//   - error: Database or encoding error
+func exportDossierConfig(ctx context.Context, db *sql.DB, id int) (string, error) {
+	var config models.DossierConfig
+	err := database.ScanConfig(db.QueryRowContext(ctx, `
+		SELECT `+database.ConfigColumns+`
+		FROM dossier_configs WHERE id = $1
+	`, id), &config)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	feedURLs, err := database.ResolveFeedURLs(ctx, db, &config)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve feeds: %w", err)
+	}
+
+	doc := portableConfig{
+		Version:                 portableConfigVersion,
+		Title:                   config.Title,
+		Email:                   config.Email,
+		FeedURLs:                feedURLs,
+		ArticleCount:            config.ArticleCount,
+		Frequency:               config.Frequency,
+		DeliveryTime:            config.DeliveryTime,
+		Timezone:                config.Timezone,
+		Tone:                    config.Tone,
+		Language:                config.Language,
+		SpecialInstructions:     config.SpecialInstructions,
+		Interests:               config.Interests,
+		EnforceLanguage:         config.EnforceLanguage,
+		IncludeExecutiveSummary: config.IncludeExecutiveSummary,
+		IncludeConclusion:       config.IncludeConclusion,
+		SkipWeekends:            config.SkipWeekends,
+		SkipDates:               config.SkipDates,
+		MinArticles:             config.MinArticles,
+		MaxPerSource:            config.MaxPerSource,
+		CTALabel:                config.CTALabel,
+		FooterText:              config.FooterText,
+		ArticlesOnlyFallback:    config.ArticlesOnlyFallback,
+		OrderByImportance:       config.OrderByImportance,
+		PreserveTitles:          config.PreserveTitles,
+		AttachPDF:               config.AttachPDF,
+		Pipeline:                config.Pipeline,
+		Temperature:             config.Temperature,
+		UseFeedContent:          config.UseFeedContent,
+		SplitByCategory:         config.SplitByCategory,
+		RecipientName:           config.RecipientName,
+		SinceLastDelivery:       config.SinceLastDelivery,
+		SponsoredFilter:         config.SponsoredFilter,
+		SponsoredPatterns:       config.SponsoredPatterns,
+		Distribution:            config.Distribution,
+		ToneIntensity:           config.ToneIntensity,
+		CandidatePoolSize:       config.CandidatePoolSize,
+	}
+	for _, day := range config.DeliveryWeekdays {
+		doc.DeliveryWeekdays = append(doc.DeliveryWeekdays, int(day))
+	}
+
+	// System tones exist on every instance; only custom prompts travel
+	err = db.QueryRowContext(ctx, `
+		SELECT prompt FROM tones WHERE name = $1 AND is_system_default = false
+	`, config.Tone).Scan(&doc.TonePrompt)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to load tone: %w", err)
+	}
+
+	if config.RollupSourceID != nil {
+		err = db.QueryRowContext(ctx, `
+			SELECT title FROM dossier_configs WHERE id = $1
+		`, *config.RollupSourceID).Scan(&doc.RollupSource)
+		if err != nil && err != sql.ErrNoRows {
+			return "", fmt.Errorf("failed to load rollup source: %w", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// importDossierConfig creates a configuration from a portable JSON document.
+//
+// The document is validated exactly like createDossierConfig input. The tone
+// is matched by name on this instance; a missing tone is recreated from
+// tonePrompt when the document carries one, and otherwise falls back to
+// "professional". A rollupSource is matched by title and must be unique.
+//
+// Parameters:
+//   - ctx: Request context
+//   - db: Database connection
+//   - document: JSON produced by exportDossierConfig
+//
+// Returns:
+//   - *models.DossierConfig: Newly created configuration
+//   - error: *ValidationError for malformed or invalid documents, or a database error
+func importDossierConfig(ctx context.Context, db *sql.DB, document string) (*models.DossierConfig, error) {
+	var doc portableConfig
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, &ValidationError{Fields: []FieldError{{Field: "json", Message: "must be a dossier config export: " + err.Error()}}}
+	}
+	if doc.Version != portableConfigVersion {
+		return nil, &ValidationError{Fields: []FieldError{{Field: "version", Message: fmt.Sprintf("unsupported export version %d", doc.Version)}}}
+	}
+
+	input := doc.input()
+	if doc.RollupSource != "" {
+		var ids []int
+		rows, err := db.QueryContext(ctx, `SELECT id FROM dossier_configs WHERE title = $1`, doc.RollupSource)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if len(ids) != 1 {
+			return nil, &ValidationError{Fields: []FieldError{{Field: "rollupSource", Message: fmt.Sprintf("expected exactly one configuration titled %q, found %d", doc.RollupSource, len(ids))}}}
+		}
+		input["rollupSourceId"] = ids[0]
+	}
+
+	in, err := dossierConfigFromInput(ctx, db, input, 0)
+	if err != nil {
+		return nil, err
+	}
+	if in.Tone, err = importTone(ctx, db, in.Tone, doc.TonePrompt); err != nil {
+		return nil, err
+	}
+
+	config, err := insertDossierConfig(ctx, db, in)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Imported dossier config: %s", config.Title)
+	return config, nil
+}
+
+// importTone maps an imported tone name onto this instance's tones.
+//
+// Parameters:
+//   - ctx: Request context
+//   - db: Database connection
+//   - name: Tone name from the document
+//   - prompt: Custom tone prompt from the document ("" for system tones)
+//
+// Returns:
+//   - string: Tone name to store on the imported config
+//   - error: Database error or an invalid tone prompt
+func importTone(ctx context.Context, db *sql.DB, name, prompt string) (string, error) {
+	var exists bool
+	err := db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM tones WHERE name = $1)`, name).Scan(&exists)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return name, nil
+	}
+
+	if prompt == "" {
+		log.Printf("Imported tone %q not found, falling back to professional", name)
+		return "professional", nil
+	}
+
+	name, prompt, err = toneFromInput(map[string]interface{}{"name": name, "prompt": prompt})
+	if err != nil {
+		return "", err
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO tones (name, prompt) VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING
+	`, name, prompt)
+	if err != nil {
+		return "", fmt.Errorf("failed to create tone %q: %w", name, err)
+	}
+	log.Printf("Created custom tone %q for imported config", name)
+	return name, nil
+}
@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geraldfingburke/dossier/server/internal/ai"
+	"github.com/geraldfingburke/dossier/server/internal/database"
+	"github.com/geraldfingburke/dossier/server/internal/email"
+	"github.com/geraldfingburke/dossier/server/internal/models"
+	"github.com/geraldfingburke/dossier/server/internal/rss"
+	"github.com/geraldfingburke/dossier/server/internal/scheduler"
+)
+
+// ============================================================================
+// BROWSER PREVIEW
+// ============================================================================
+
+// PreviewHandler serves GET /preview/{configId}: the configuration's dossier
+// rendered as the HTML email recipients would receive, for viewing in a
+// browser tab. Nothing is sent or recorded.
+//
+// By default the real pipeline runs: the config's feeds are fetched and
+// summarized like a manual generation (cached summaries are reused, so
+// reloading is cheap). With ?sample=true the test email's sample content is
+// rendered instead, without fetching feeds or calling the AI service, for
+// fast layout iteration.
+//
+// The route is admin-only. Wrap it in AdminMiddleware; when ADMIN_TOKEN is
+// set, browsers are prompted for it via HTTP Basic auth (any username).
+//
+// Parameters:
+//   - db: Database connection for loading the configuration
+//   - schedulerService: Article collection (the same as scheduled runs)
+//   - aiService: Summary generation
+//   - emailService: Email template rendering
+//
+// Returns:
+//   - http.Handler: Handler to mount at /preview/{configId}
+//
+// Example:
+//
+//	r.Method(http.MethodGet, "/preview/{configId}",
+//		graphql.AdminMiddleware(graphql.PreviewHandler(db, schedulerService, aiService, emailService)))
+func PreviewHandler(db *sql.DB, schedulerService *scheduler.Service, aiService *ai.Service, emailService *email.Service) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := requireAdmin(r.Context()); err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Dossier preview"`)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		configID, err := strconv.Atoi(r.PathValue("configId"))
+		if err != nil || configID <= 0 {
+			http.Error(w, "configId must be a positive integer", http.StatusBadRequest)
+			return
+		}
+
+		var config models.DossierConfig
+		err = database.ScanConfig(db.QueryRowContext(r.Context(), `
+			SELECT `+database.ConfigColumns+`
+			FROM dossier_configs WHERE id = $1
+		`, configID), &config)
+		if err == sql.ErrNoRows {
+			http.Error(w, "dossier configuration not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Printf("Preview: failed to load config %d: %v", configID, err)
+			http.Error(w, "failed to load configuration", http.StatusInternalServerError)
+			return
+		}
+
+		feedURLs, err := database.ResolveFeedURLs(r.Context(), db, &config)
+		if err != nil {
+			log.Printf("Preview: failed to resolve feeds for config %d: %v", configID, err)
+			http.Error(w, "failed to resolve feeds", http.StatusInternalServerError)
+			return
+		}
+
+		var summary string
+		var articles []models.Article
+		previewConfig := &config
+		if r.URL.Query().Get("sample") == "true" {
+			previewConfig, summary, articles = email.TestDossier(&config, feedURLs, time.Now())
+		} else {
+			if summary, articles, err = previewDossier(r, &config, feedURLs, schedulerService, aiService); err != nil {
+				status := http.StatusBadGateway
+				if errors.Is(err, rss.ErrNoArticles) || errors.Is(err, errPreviewRollup) {
+					status = http.StatusUnprocessableEntity
+				}
+				http.Error(w, err.Error(), status)
+				return
+			}
+		}
+
+		html, err := emailService.RenderDossierHTML(previewConfig, summary, articles)
+		if err != nil {
+			log.Printf("Preview: failed to render config %d: %v", configID, err)
+			http.Error(w, "failed to render email", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte(html))
+	})
+}
+
+// errPreviewRollup is returned for rollup configurations, whose dossiers are
+// built from past deliveries rather than feeds.
+var errPreviewRollup = errors.New("rollup configurations can only be previewed with ?sample=true")
+
+// previewDossier fetches and summarizes a configuration's articles the way
+// the manual generateAndSendDossier mutation does, without sending.
+//
+// Parameters:
+//   - r: Preview request (its context bounds the work)
+//   - config: Configuration to preview
+//   - feedURLs: Resolved feed URLs
+//   - schedulerService: Article collection
+//   - aiService: Summary generation
+//
+// Returns:
+//   - string: Summary HTML
+//   - []models.Article: Articles included
+//   - error: Rollup config, no articles, or a fetch or AI failure
+func previewDossier(r *http.Request, config *models.DossierConfig, feedURLs []string, schedulerService *scheduler.Service, aiService *ai.Service) (string, []models.Article, error) {
+	if config.IsRollup() {
+		return "", nil, errPreviewRollup
+	}
+	if len(feedURLs) == 0 {
+		return "", nil, rss.ErrNoArticles
+	}
+
+	articles, err := schedulerService.CollectArticles(r.Context(), *config, feedURLs, time.Time{})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(articles) == 0 {
+		return "", nil, rss.ErrNoArticles
+	}
+
+	result, err := aiService.GenerateSummary(r.Context(), articles, ai.SummaryOptionsFromConfig(config))
+	if err != nil {
+		return "", nil, err
+	}
+	if result.Included != nil {
+		articles = result.Included
+	}
+	return result.HTML, articles, nil
+}
@@ -0,0 +1,722 @@
+package graphql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/geraldfingburke/dossier/server/internal/ai"
+	"github.com/geraldfingburke/dossier/server/internal/models"
+	"github.com/geraldfingburke/dossier/server/internal/rss"
+	"github.com/graphql-go/graphql"
+)
+
+// ============================================================================
+// INPUT VALIDATION
+// ============================================================================
+
+// Limits mirrored from the database schema so bad input is reported per field
+// instead of surfacing as a constraint violation.
+const (
+	maxTitleLength    = 255 // dossier_configs.title VARCHAR(255)
+	maxEmailLength    = 255 // dossier_configs.email VARCHAR(255)
+	maxToneNameLength = 100 // tones.name VARCHAR(100)
+	maxCTALabelLength = 100 // Keeps the per-article link on one line
+	maxFooterLength   = 500 // A line or two of footer text
+	maxRecipientName  = 100 // dossier_configs.recipient_name VARCHAR(100)
+	maxSponsoredCount = 50  // Patterns are checked against every fetched item
+	maxSponsoredLen   = 100 // A label or phrase, not a paragraph
+	maxFeedPriority   = 10  // feeds.priority CHECK
+	minArticleCount   = 1   // dossier_configs.article_count CHECK
+	maxArticleCount   = 50  // dossier_configs.article_count CHECK
+	maxCandidatePool  = 100 // dossier_configs.candidate_pool_size CHECK
+	maxSandboxSends   = 20  // Sandbox mode is for tuning, not a permanent schedule
+	maxTemperature    = 2.0 // dossier_configs.temperature CHECK
+)
+
+// defaultMaxFeedsPerConfig caps feedUrls and feedIds combined when
+// MAX_FEEDS_PER_CONFIG is not set. Every feed is fetched on every run, so
+// one oversized config could otherwise monopolize the scheduler.
+const defaultMaxFeedsPerConfig = 25
+
+// maxFeedsPerConfig is the feed limit in effect, set from
+// MAX_FEEDS_PER_CONFIG by Handler.
+var maxFeedsPerConfig = defaultMaxFeedsPerConfig
+
+// validFrequencies lists the accepted dossier_configs.frequency values.
+var validFrequencies = map[string]bool{"daily": true, "weekly": true, "monthly": true}
+
+// FieldError describes a single invalid input field.
+type FieldError struct {
+	Field   string `json:"field"`   // Input field name (e.g. "articleCount", "feedUrls[2]")
+	Message string `json:"message"` // Human-readable problem description
+}
+
+// ValidationError reports every invalid field in a mutation input at once.
+//
+// It implements graphql-go's ExtendedError, so the GraphQL response carries
+// the individual problems for per-field display:
+//
+//	"extensions": {
+//	  "code": "VALIDATION_FAILED",
+//	  "fields": [{"field": "email", "message": "must be a valid email address"}]
+//	}
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error joins all field problems into a single message.
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		parts[i] = field.Field + ": " + field.Message
+	}
+	return "invalid input: " + strings.Join(parts, "; ")
+}
+
+// Extensions exposes the field errors in the GraphQL error response.
+func (e *ValidationError) Extensions() map[string]interface{} {
+	fields := make([]map[string]interface{}, len(e.Fields))
+	for i, field := range e.Fields {
+		fields[i] = map[string]interface{}{"field": field.Field, "message": field.Message}
+	}
+	return map[string]interface{}{
+		"code":   "VALIDATION_FAILED",
+		"fields": fields,
+	}
+}
+
+// inputValidator reads fields from a GraphQL input object using checked type
+// assertions. Problems are recorded rather than returned immediately, so one
+// call to err reports everything wrong with the input.
+//
+// Missing optional fields yield the supplied fallback. A field with the wrong
+// type yields the zero value (or fallback) and records an error; callers can
+// keep reading and validate the rest.
+type inputValidator struct {
+	input  map[string]interface{}
+	errors []FieldError
+}
+
+// newInputValidator wraps a GraphQL input object. A nil map is treated as an
+// empty input, so every required field is reported missing.
+func newInputValidator(input map[string]interface{}) *inputValidator {
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+	return &inputValidator{input: input}
+}
+
+// addError records a problem with a field.
+func (v *inputValidator) addError(field, format string, args ...interface{}) {
+	v.errors = append(v.errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// has reports whether a field was provided with a non-null value.
+func (v *inputValidator) has(field string) bool {
+	return v.input[field] != nil
+}
+
+// requiredString returns a trimmed, non-empty string field.
+func (v *inputValidator) requiredString(field string) string {
+	if !v.has(field) {
+		v.addError(field, "is required")
+		return ""
+	}
+	value, ok := v.input[field].(string)
+	if !ok {
+		v.addError(field, "must be a string")
+		return ""
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		v.addError(field, "must not be empty")
+	}
+	return value
+}
+
+// optionalString returns a string field as given, or fallback when absent.
+func (v *inputValidator) optionalString(field, fallback string) string {
+	if !v.has(field) {
+		return fallback
+	}
+	value, ok := v.input[field].(string)
+	if !ok {
+		v.addError(field, "must be a string")
+		return fallback
+	}
+	return value
+}
+
+// requiredInt returns an integer field.
+func (v *inputValidator) requiredInt(field string) int {
+	if !v.has(field) {
+		v.addError(field, "is required")
+		return 0
+	}
+	value, ok := v.input[field].(int)
+	if !ok {
+		v.addError(field, "must be an integer")
+		return 0
+	}
+	return value
+}
+
+// optionalInt returns an integer field, or fallback when absent.
+func (v *inputValidator) optionalInt(field string, fallback int) int {
+	if !v.has(field) {
+		return fallback
+	}
+	value, ok := v.input[field].(int)
+	if !ok {
+		v.addError(field, "must be an integer")
+		return fallback
+	}
+	return value
+}
+
+// optionalBool returns a boolean field, or fallback when absent.
+func (v *inputValidator) optionalBool(field string, fallback bool) bool {
+	if !v.has(field) {
+		return fallback
+	}
+	value, ok := v.input[field].(bool)
+	if !ok {
+		v.addError(field, "must be a boolean")
+		return fallback
+	}
+	return value
+}
+
+// list returns a list field's raw items (nil when absent).
+func (v *inputValidator) list(field string) []interface{} {
+	if !v.has(field) {
+		return nil
+	}
+	items, ok := v.input[field].([]interface{})
+	if !ok {
+		v.addError(field, "must be a list")
+		return nil
+	}
+	return items
+}
+
+// stringList returns a list of trimmed strings, dropping null and blank items.
+func (v *inputValidator) stringList(field string) []string {
+	values := []string{}
+	for i, raw := range v.list(field) {
+		if raw == nil {
+			continue
+		}
+		value, ok := raw.(string)
+		if !ok {
+			v.addError(fmt.Sprintf("%s[%d]", field, i), "must be a string")
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// maxLength records an error when a string exceeds max characters.
+func (v *inputValidator) maxLength(field, value string, max int) {
+	if len([]rune(value)) > max {
+		v.addError(field, "must be at most %d characters", max)
+	}
+}
+
+// err returns a *ValidationError listing every recorded problem, or nil.
+func (v *inputValidator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.errors}
+}
+
+// ============================================================================
+// RESOLVER ARGUMENTS
+// ============================================================================
+
+// Resolvers read arguments through these helpers rather than bare type
+// assertions. graphql-go coerces arguments to their declared types before
+// resolving, but a schema/resolver mismatch or a malformed request must come
+// back as a GraphQL error, not a panic in the handler goroutine.
+
+// stringArg returns a required String or ID argument.
+func stringArg(p graphql.ResolveParams, name string) (string, error) {
+	value, ok := p.Args[name].(string)
+	if !ok {
+		return "", argError(p, name, "must be a string")
+	}
+	return value, nil
+}
+
+// intArg returns a required Int argument.
+func intArg(p graphql.ResolveParams, name string) (int, error) {
+	value, ok := p.Args[name].(int)
+	if !ok {
+		return 0, argError(p, name, "must be an integer")
+	}
+	return value, nil
+}
+
+// idArg returns a required ID argument parsed as an integer.
+func idArg(p graphql.ResolveParams, name string) (int, error) {
+	raw, err := stringArg(p, name)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, argError(p, name, fmt.Sprintf("invalid id %q", raw))
+	}
+	return id, nil
+}
+
+// inputArg returns a required input object argument.
+func inputArg(p graphql.ResolveParams, name string) (map[string]interface{}, error) {
+	value, ok := p.Args[name].(map[string]interface{})
+	if !ok {
+		return nil, argError(p, name, "must be an input object")
+	}
+	return value, nil
+}
+
+// argError reports a missing or mistyped argument as a ValidationError.
+func argError(p graphql.ResolveParams, name, message string) error {
+	if p.Args[name] == nil {
+		message = "is required"
+	}
+	return &ValidationError{Fields: []FieldError{{Field: name, Message: message}}}
+}
+
+// ============================================================================
+// MUTATION INPUTS
+// ============================================================================
+
+// dossierConfigFromInput validates a DossierConfigInput and builds the
+// configuration it describes, applying defaults for optional fields.
+//
+// Validation:
+//   - title, email, frequency, deliveryTime, timezone: required and well-formed
+//   - feedUrls: http(s) URLs; blank entries are dropped
+//   - articleCount: 1-50; minArticles: 1-articleCount
+//   - skipDates: YYYY-MM-DD; feedIds: existing feeds; rollupSourceId: existing non-rollup config
+//   - feedUrls and feedIds together: at most MAX_FEEDS_PER_CONFIG (default: 25)
+//   - deliveryWeekdays: 0-6, weekly frequency only; sandboxSends: 0-20
+//   - sponsoredFilter: drop, demote, or off; sponsoredPatterns: at most 50
+//   - distribution: even, priority, or newest; toneIntensity: subtle, normal, or strong
+//   - candidatePoolSize: 0, or articleCount-100
+//   - At least one feed URL or feed ID unless the config is a rollup
+//
+// Default Values Applied:
+//   - tone: "professional", language: "English"
+//   - specialInstructions, interests: "" (empty)
+//   - enforceLanguage, skipWeekends: false
+//   - includeExecutiveSummary, includeConclusion: true
+//   - minArticles: 1
+//   - pipeline: "robust"
+//   - sponsoredFilter: "drop"
+//   - distribution: "even"
+//   - toneIntensity: "normal"
+//   - candidatePoolSize: 0 (articleCount)
+//
+// Parameters:
+//   - ctx: Request context
+//   - db: Database connection (for feed and rollup source lookups)
+//   - input: DossierConfigInput arguments
+//   - selfID: ID of the config being updated (0 when creating)
+//
+// Returns:
+//   - *models.DossierConfig: Configuration ready to insert or update (ID unset)
+//   - error: *ValidationError listing every invalid field, or a database error
+func dossierConfigFromInput(ctx context.Context, db *sql.DB, input map[string]interface{}, selfID int) (*models.DossierConfig, error) {
+	v := newInputValidator(input)
+	config := &models.DossierConfig{}
+
+	config.Title = v.requiredString("title")
+	v.maxLength("title", config.Title, maxTitleLength)
+
+	config.Email = v.requiredString("email")
+	if config.Email != "" {
+		if _, err := mail.ParseAddress(config.Email); err != nil {
+			v.addError("email", "must be a valid email address")
+		}
+		v.maxLength("email", config.Email, maxEmailLength)
+	}
+
+	if !v.has("feedUrls") {
+		v.addError("feedUrls", "is required")
+	}
+	config.FeedURLs = v.stringList("feedUrls")
+	for i, feedURL := range config.FeedURLs {
+		parsed, err := url.Parse(feedURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			v.addError(fmt.Sprintf("feedUrls[%d]", i), "must be an http or https URL")
+		}
+	}
+
+	config.ArticleCount = v.requiredInt("articleCount")
+	if v.has("articleCount") && (config.ArticleCount < minArticleCount || config.ArticleCount > maxArticleCount) {
+		v.addError("articleCount", "must be between %d and %d", minArticleCount, maxArticleCount)
+	}
+
+	config.Frequency = v.requiredString("frequency")
+	if config.Frequency != "" && !validFrequencies[config.Frequency] {
+		v.addError("frequency", "must be one of daily, weekly, monthly")
+	}
+
+	config.DeliveryTime = v.requiredString("deliveryTime")
+	if config.DeliveryTime != "" {
+		if _, err := time.Parse("15:04", config.DeliveryTime); err != nil {
+			if _, err := time.Parse("15:04:05", config.DeliveryTime); err != nil {
+				v.addError("deliveryTime", "must be HH:MM or HH:MM:SS")
+			}
+		}
+	}
+
+	config.Timezone = v.requiredString("timezone")
+	if config.Timezone != "" {
+		if _, err := time.LoadLocation(config.Timezone); err != nil {
+			v.addError("timezone", "unknown timezone %q", config.Timezone)
+		}
+	}
+
+	config.Tone = v.optionalString("tone", "professional")
+	config.Language = v.optionalString("language", "English")
+	config.SpecialInstructions = v.optionalString("specialInstructions", "")
+	if err := ai.CheckInstructions(config.SpecialInstructions); err != nil {
+		v.addError("specialInstructions", "invalid template: %v", err)
+	}
+	config.Interests = v.optionalString("interests", "")
+	config.EnforceLanguage = v.optionalBool("enforceLanguage", false)
+	config.IncludeExecutiveSummary = v.optionalBool("includeExecutiveSummary", true)
+	config.IncludeConclusion = v.optionalBool("includeConclusion", true)
+	config.SkipWeekends = v.optionalBool("skipWeekends", false)
+	config.SkipDates = v.skipDates()
+
+	config.MinArticles = v.optionalInt("minArticles", 1)
+	if v.has("minArticles") && (config.MinArticles < 1 || (config.ArticleCount > 0 && config.MinArticles > config.ArticleCount)) {
+		v.addError("minArticles", "must be between 1 and articleCount")
+	}
+
+	config.DeliveryWeekdays = v.deliveryWeekdays()
+	if len(config.DeliveryWeekdays) > 0 && config.Frequency != "" && config.Frequency != "weekly" {
+		v.addError("deliveryWeekdays", "only applies to weekly frequency")
+	}
+
+	config.MaxPerSource = v.optionalInt("maxPerSource", 0)
+	if config.MaxPerSource < 0 {
+		v.addError("maxPerSource", "must be 0 (no limit) or greater")
+	}
+
+	config.CTALabel = strings.TrimSpace(v.optionalString("ctaLabel", ""))
+	v.maxLength("ctaLabel", config.CTALabel, maxCTALabelLength)
+
+	config.FooterText = strings.TrimSpace(v.optionalString("footerText", ""))
+	v.maxLength("footerText", config.FooterText, maxFooterLength)
+
+	config.ArticlesOnlyFallback = v.optionalBool("articlesOnlyFallback", false)
+
+	config.OrderByImportance = v.optionalBool("orderByImportance", false)
+
+	config.PreserveTitles = v.optionalBool("preserveTitles", false)
+
+	config.AttachPDF = v.optionalBool("attachPdf", false)
+
+	config.SandboxSends = v.optionalInt("sandboxSends", 0)
+	if config.SandboxSends < 0 || config.SandboxSends > maxSandboxSends {
+		v.addError("sandboxSends", "must be between 0 (off) and %d", maxSandboxSends)
+	}
+
+	config.Pipeline = v.optionalString("pipeline", ai.PipelineRobust)
+	if config.Pipeline == "" {
+		config.Pipeline = ai.PipelineRobust
+	}
+	if config.Pipeline != ai.PipelineRobust && config.Pipeline != ai.PipelineSimple {
+		v.addError("pipeline", "must be one of robust, simple")
+	}
+
+	if v.has("temperature") {
+		temperature, ok := v.input["temperature"].(float64)
+		if !ok || temperature < 0 || temperature > maxTemperature {
+			v.addError("temperature", "must be a number between 0 and %g", maxTemperature)
+		} else {
+			config.Temperature = &temperature
+		}
+	}
+
+	config.UseFeedContent = v.optionalBool("useFeedContent", false)
+	config.SplitByCategory = v.optionalBool("splitByCategory", false)
+
+	config.RecipientName = strings.TrimSpace(v.optionalString("recipientName", ""))
+	v.maxLength("recipientName", config.RecipientName, maxRecipientName)
+
+	config.SinceLastDelivery = v.optionalBool("sinceLastDelivery", false)
+
+	config.SponsoredFilter = v.optionalString("sponsoredFilter", rss.SponsoredDrop)
+	if config.SponsoredFilter == "" {
+		config.SponsoredFilter = rss.SponsoredDrop
+	}
+	if !rss.ValidSponsoredFilter(config.SponsoredFilter) {
+		v.addError("sponsoredFilter", "must be one of drop, demote, off")
+	}
+	config.SponsoredPatterns = v.stringList("sponsoredPatterns")
+	if len(config.SponsoredPatterns) > maxSponsoredCount {
+		v.addError("sponsoredPatterns", "at most %d patterns are allowed", maxSponsoredCount)
+	}
+	for i, pattern := range config.SponsoredPatterns {
+		field := fmt.Sprintf("sponsoredPatterns[%d]", i)
+		v.maxLength(field, pattern, maxSponsoredLen)
+		if err := rss.CheckSponsoredPattern(pattern); err != nil {
+			v.addError(field, "%v", err)
+		}
+	}
+
+	config.Distribution = v.optionalString("distribution", rss.DistributionEven)
+	if config.Distribution == "" {
+		config.Distribution = rss.DistributionEven
+	}
+	if !rss.ValidDistribution(config.Distribution) {
+		v.addError("distribution", "must be one of even, priority, newest")
+	}
+
+	config.ToneIntensity = v.optionalString("toneIntensity", ai.ToneIntensityNormal)
+	if config.ToneIntensity == "" {
+		config.ToneIntensity = ai.ToneIntensityNormal
+	}
+	if !ai.ValidToneIntensity(config.ToneIntensity) {
+		v.addError("toneIntensity", "must be one of subtle, normal, strong")
+	}
+
+	config.CandidatePoolSize = v.optionalInt("candidatePoolSize", 0)
+	if config.CandidatePoolSize != 0 && (config.CandidatePoolSize < config.ArticleCount || config.CandidatePoolSize > maxCandidatePool) {
+		v.addError("candidatePoolSize", "must be 0 or between articleCount and %d", maxCandidatePool)
+	}
+
+	var err error
+	if config.FeedIDs, err = v.feedIDs(ctx, db); err != nil {
+		return nil, err
+	}
+	if config.RollupSourceID, err = v.rollupSource(ctx, db, selfID); err != nil {
+		return nil, err
+	}
+
+	if config.SplitByCategory && config.RollupSourceID != nil {
+		v.addError("splitByCategory", "cannot be used with rollupSourceId; rollups have no feeds to split")
+	}
+	if !v.has("rollupSourceId") && len(config.FeedURLs) == 0 && len(config.FeedIDs) == 0 {
+		v.addError("feedUrls", "at least one feed URL or feed ID is required")
+	}
+	if feeds := len(config.FeedURLs) + len(config.FeedIDs); feeds > maxFeedsPerConfig {
+		v.addError("feedUrls", "at most %d feeds are allowed per configuration (feedUrls and feedIds combined), got %d",
+			maxFeedsPerConfig, feeds)
+	}
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// feedFromInput validates a FeedInput, applying defaults for the optional
+// fields (empty metadata, priority 1, active).
+//
+// Parameters:
+//   - input: FeedInput arguments
+//
+// Returns:
+//   - models.Feed: Feed fields to insert or update (ID unset)
+//   - error: *ValidationError listing every invalid field
+func feedFromInput(input map[string]interface{}) (models.Feed, error) {
+	v := newInputValidator(input)
+	feed := models.Feed{
+		URL:         v.requiredString("url"),
+		Title:       v.optionalString("title", ""),
+		Description: v.optionalString("description", ""),
+		Category:    v.optionalString("category", ""),
+		Priority:    v.optionalInt("priority", 1),
+		Active:      v.optionalBool("active", true),
+	}
+	if feed.Priority < 1 || feed.Priority > maxFeedPriority {
+		v.addError("priority", "must be between 1 and %d", maxFeedPriority)
+	}
+	if feed.URL != "" {
+		parsed, err := url.Parse(feed.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			v.addError("url", "must be an http or https URL")
+		}
+	}
+	return feed, v.err()
+}
+
+// toneFromInput validates a ToneInput.
+//
+// Parameters:
+//   - input: ToneInput arguments
+//
+// Returns:
+//   - name: Trimmed tone name (1-100 characters)
+//   - prompt: Trimmed, non-empty tone prompt
+//   - err: *ValidationError listing every invalid field
+func toneFromInput(input map[string]interface{}) (name, prompt string, err error) {
+	v := newInputValidator(input)
+	name = v.requiredString("name")
+	v.maxLength("name", name, maxToneNameLength)
+	prompt = v.requiredString("prompt")
+	return name, prompt, v.err()
+}
+
+// skipDates validates the optional skipDates list.
+//
+// Dates must use YYYY-MM-DD. They are interpreted in the configuration's
+// timezone by the scheduler, so no zone is attached here. Duplicates are
+// dropped.
+//
+// Returns:
+//   - []string: Normalized dates (empty when not provided)
+func (v *inputValidator) skipDates() []string {
+	dates := []string{}
+	seen := make(map[string]bool)
+	for i, value := range v.stringList("skipDates") {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			v.addError(fmt.Sprintf("skipDates[%d]", i), "invalid date %q: expected YYYY-MM-DD", value)
+			continue
+		}
+		normalized := parsed.Format("2006-01-02")
+		if !seen[normalized] {
+			seen[normalized] = true
+			dates = append(dates, normalized)
+		}
+	}
+	return dates
+}
+
+// deliveryWeekdays validates the optional deliveryWeekdays list.
+//
+// Days use time.Weekday numbering (0=Sunday through 6=Saturday). The result
+// is sorted and free of duplicates.
+//
+// Returns:
+//   - []int64: Weekdays (empty when not provided)
+func (v *inputValidator) deliveryWeekdays() []int64 {
+	var present [7]bool
+	for i, raw := range v.list("deliveryWeekdays") {
+		if raw == nil {
+			continue
+		}
+		day, ok := raw.(int)
+		if !ok || day < 0 || day > 6 {
+			v.addError(fmt.Sprintf("deliveryWeekdays[%d]", i), "must be 0 (Sunday) through 6 (Saturday)")
+			continue
+		}
+		present[day] = true
+	}
+
+	days := []int64{}
+	for day, ok := range present {
+		if ok {
+			days = append(days, int64(day))
+		}
+	}
+	return days
+}
+
+// feedIDs validates the optional feedIds list.
+//
+// Every ID must refer to an existing feed; duplicates are dropped. Inactive
+// feeds are accepted (they are simply skipped at fetch time).
+//
+// Parameters:
+//   - ctx: Request context
+//   - db: Database connection
+//
+// Returns:
+//   - []int64: Feed IDs (empty when not provided)
+//   - error: Database error only; unknown feeds are recorded as field errors
+func (v *inputValidator) feedIDs(ctx context.Context, db *sql.DB) ([]int64, error) {
+	ids := []int64{}
+	seen := make(map[int64]bool)
+	for i, raw := range v.list("feedIds") {
+		if raw == nil {
+			continue
+		}
+		value, ok := raw.(int)
+		if !ok {
+			v.addError(fmt.Sprintf("feedIds[%d]", i), "must be an integer")
+			continue
+		}
+		id := int64(value)
+		if seen[id] {
+			continue
+		}
+
+		var exists bool
+		err := db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM feeds WHERE id = $1)`, id).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			v.addError(fmt.Sprintf("feedIds[%d]", i), "feed %d not found", id)
+			continue
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// rollupSource validates the optional rollupSourceId.
+//
+// A rollup must reference an existing, non-rollup configuration other than
+// itself, so rollups cannot chain or loop.
+//
+// Parameters:
+//   - ctx: Request context
+//   - db: Database connection
+//   - selfID: ID of the config being updated (0 when creating)
+//
+// Returns:
+//   - *int: Source config ID, or nil for a regular config
+//   - error: Database error only; invalid sources are recorded as field errors
+func (v *inputValidator) rollupSource(ctx context.Context, db *sql.DB, selfID int) (*int, error) {
+	if !v.has("rollupSourceId") {
+		return nil, nil
+	}
+	sourceID, ok := v.input["rollupSourceId"].(int)
+	if !ok {
+		v.addError("rollupSourceId", "must be an integer")
+		return nil, nil
+	}
+	if sourceID == selfID {
+		v.addError("rollupSourceId", "a rollup cannot summarize itself")
+		return nil, nil
+	}
+
+	var sourceRollupID sql.NullInt64
+	err := db.QueryRowContext(ctx, `
+		SELECT rollup_source_id FROM dossier_configs WHERE id = $1
+	`, sourceID).Scan(&sourceRollupID)
+	if err == sql.ErrNoRows {
+		v.addError("rollupSourceId", "configuration %d not found", sourceID)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sourceRollupID.Valid {
+		v.addError("rollupSourceId", "configuration %d is itself a rollup", sourceID)
+		return nil, nil
+	}
+
+	return &sourceID, nil
+}
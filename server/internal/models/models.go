@@ -72,9 +72,40 @@ import (
 //   - Tone: AI tone preset name (references Tone.Name)
 //   - Language: Target language for AI summaries (e.g., "English", "Spanish")
 //   - SpecialInstructions: Custom AI instructions (optional)
+//   - Interests: Reader interests (free text or keywords) used to rank articles by relevance (optional)
+//   - EnforceLanguage: Verify generated text is in Language and translate it when not
+//   - IncludeExecutiveSummary: Generate and include the executive summary section (default true)
+//   - IncludeConclusion: Generate and include the conclusion section (default true)
+//   - SkipWeekends: Skip scheduled deliveries on Saturdays and Sundays (config timezone)
+//   - SkipDates: Dates (YYYY-MM-DD, config timezone) with no scheduled delivery, e.g. holidays
+//   - FeedIDs: IDs of shared feeds (feeds table) aggregated alongside FeedURLs
+//   - MinArticles: Fewest fetched articles worth sending; below this the delivery is skipped (default 1)
+//   - DeliveryWeekdays: Weekly schedules deliver on each of these days (0=Sunday..6=Saturday); empty means Mondays only
+//   - MaxPerSource: Most articles taken from any one domain before selection (0 = no limit)
+//   - CTALabel: Link text for each article's "read more" link (empty = "Read full article")
+//   - FooterText: Email footer line (empty = built-in Dossier footer)
+//   - ArticlesOnlyFallback: Send the article list without a summary when AI generation fails
+//   - OrderByImportance: Have the AI rank articles and order the email by that ranking
+//   - PreserveTitles: Keep article titles verbatim (untranslated) in generated text
+//   - AttachPDF: Attach a PDF rendering of the dossier to each email (needs PDF_RENDER_COMMAND)
+//   - SandboxSends: Sandbox deliveries left; while > 0 the schedule is replaced by SANDBOX_INTERVAL
+//   - Pipeline: Generation pipeline, "robust" (scrape and summarize each article) or "simple" (one call, no scraping)
+//   - Temperature: Model sampling temperature override (nil uses OLLAMA_TEMPERATURE)
+//   - UseFeedContent: Use the feed's own article text instead of scraping article pages
+//   - SplitByCategory: Send one dossier per feed category instead of one combined email
+//   - RecipientName: Name used in the email greeting, e.g. "Good morning, Alex" (empty = no greeting)
+//   - SinceLastDelivery: Only include articles published after the previous sent dossier
+//   - SponsoredFilter: What happens to sponsored/ad-labeled items: "drop", "demote", or "off"
+//   - SponsoredPatterns: Patterns marking sponsored items (empty = server defaults, see rss.FilterSponsored)
+//   - ToneIntensity: How strongly the tone is applied: "subtle", "normal", or "strong"
+//   - Distribution: How ArticleCount is divided between feeds: "even", "priority", or "newest" (see rss.Distribute)
+//   - CandidatePoolSize: Articles considered for selection; those ranked below ArticleCount are listed as "also noted" (0 = ArticleCount)
 //   - Active: Whether automated delivery is enabled
 //   - CreatedAt: Configuration creation timestamp
 //   - UpdatedAt: Last modification timestamp
+//   - RollupSourceID: When set, this is a rollup ("digest of digests") config
+//     that summarizes the referenced config's recent deliveries instead of
+//     fetching feeds; FeedURLs may then be empty
 //
 // Validation:
 //   - Title: Required, non-empty
@@ -102,20 +133,55 @@ import (
 //	    Active:       true,
 //	}
 type DossierConfig struct {
-	ID                  int       `json:"id" db:"id"`
-	Title               string    `json:"title" db:"title"`
-	Email               string    `json:"email" db:"email"`
-	FeedURLs            []string  `json:"feed_urls" db:"feed_urls"`
-	ArticleCount        int       `json:"article_count" db:"article_count"`
-	Frequency           string    `json:"frequency" db:"frequency"`
-	DeliveryTime        string    `json:"delivery_time" db:"delivery_time"`
-	Timezone            string    `json:"timezone" db:"timezone"`
-	Tone                string    `json:"tone" db:"tone"`
-	Language            string    `json:"language" db:"language"`
-	SpecialInstructions string    `json:"special_instructions" db:"special_instructions"`
-	Active              bool      `json:"active" db:"active"`
-	CreatedAt           time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+	ID                      int       `json:"id" db:"id"`
+	Title                   string    `json:"title" db:"title"`
+	Email                   string    `json:"email" db:"email"`
+	FeedURLs                []string  `json:"feed_urls" db:"feed_urls"`
+	ArticleCount            int       `json:"article_count" db:"article_count"`
+	Frequency               string    `json:"frequency" db:"frequency"`
+	DeliveryTime            string    `json:"delivery_time" db:"delivery_time"`
+	Timezone                string    `json:"timezone" db:"timezone"`
+	Tone                    string    `json:"tone" db:"tone"`
+	Language                string    `json:"language" db:"language"`
+	SpecialInstructions     string    `json:"special_instructions" db:"special_instructions"`
+	Interests               string    `json:"interests" db:"interests"`
+	EnforceLanguage         bool      `json:"enforce_language" db:"enforce_language"`
+	Active                  bool      `json:"active" db:"active"`
+	CreatedAt               time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt               time.Time `json:"updated_at" db:"updated_at"`
+	RollupSourceID          *int      `json:"rollup_source_id" db:"rollup_source_id"`
+	IncludeExecutiveSummary bool      `json:"include_executive_summary" db:"include_executive_summary"`
+	IncludeConclusion       bool      `json:"include_conclusion" db:"include_conclusion"`
+	SkipWeekends            bool      `json:"skip_weekends" db:"skip_weekends"`
+	SkipDates               []string  `json:"skip_dates" db:"skip_dates"`
+	FeedIDs                 []int64   `json:"feed_ids" db:"feed_ids"`
+	MinArticles             int       `json:"min_articles" db:"min_articles"`
+	DeliveryWeekdays        []int64   `json:"delivery_weekdays" db:"delivery_weekdays"`
+	MaxPerSource            int       `json:"max_per_source" db:"max_per_source"`
+	CTALabel                string    `json:"cta_label" db:"cta_label"`
+	FooterText              string    `json:"footer_text" db:"footer_text"`
+	ArticlesOnlyFallback    bool      `json:"articles_only_fallback" db:"articles_only_fallback"`
+	OrderByImportance       bool      `json:"order_by_importance" db:"order_by_importance"`
+	PreserveTitles          bool      `json:"preserve_titles" db:"preserve_titles"`
+	AttachPDF               bool      `json:"attach_pdf" db:"attach_pdf"`
+	SandboxSends            int       `json:"sandbox_sends" db:"sandbox_sends"`
+	Pipeline                string    `json:"pipeline" db:"pipeline"`
+	Temperature             *float64  `json:"temperature" db:"temperature"`
+	UseFeedContent          bool      `json:"use_feed_content" db:"use_feed_content"`
+	SplitByCategory         bool      `json:"split_by_category" db:"split_by_category"`
+	RecipientName           string    `json:"recipient_name" db:"recipient_name"`
+	SinceLastDelivery       bool      `json:"since_last_delivery" db:"since_last_delivery"`
+	SponsoredFilter         string    `json:"sponsored_filter" db:"sponsored_filter"`
+	SponsoredPatterns       []string  `json:"sponsored_patterns" db:"sponsored_patterns"`
+	Distribution            string    `json:"distribution" db:"distribution"`
+	ToneIntensity           string    `json:"tone_intensity" db:"tone_intensity"`
+	CandidatePoolSize       int       `json:"candidate_pool_size" db:"candidate_pool_size"`
+}
+
+// IsRollup reports whether the configuration summarizes another config's
+// deliveries rather than fetching its own feeds.
+func (c *DossierConfig) IsRollup() bool {
+	return c.RollupSourceID != nil
 }
 
 // ============================================================================
@@ -192,23 +258,26 @@ func (a *StringArray) Scan(value interface{}) error {
 // Feeds are the content sources for dossiers. Each feed represents one RSS/Atom
 // feed URL from which articles are periodically fetched.
 //
-// Note: In the current architecture, feeds are referenced by URL in DossierConfig.FeedURLs
-// rather than by foreign key. This table tracks feed metadata and fetch history.
+// Configurations can reference feeds by ID through DossierConfig.FeedIDs, which
+// lets several dossiers share one feed and its metadata. Raw URLs in
+// DossierConfig.FeedURLs remain supported for backward compatibility.
 //
 // Field Descriptions:
 //   - ID: Unique identifier
 //   - URL: RSS/Atom feed URL (must be unique)
 //   - Title: Feed title (extracted from RSS metadata)
 //   - Description: Feed description (from RSS metadata)
-//   - Active: Whether this feed is available for use
-//   - LastFetched: Timestamp of most recent successful fetch
+//   - Category: Free-form grouping label (e.g., "tech", "local")
+//   - Priority: Weight (1-10) under the "priority" article distribution
+//   - Active: Whether this feed is available for use (inactive feeds are skipped)
+//   - LastFetched: Timestamp of most recent successful fetch (nil if never fetched)
 //   - CreatedAt: Feed registration timestamp
 //   - UpdatedAt: Last modification timestamp
 //
 // Lifecycle:
-//   - Auto-created when first referenced in a DossierConfig
-//   - Updated on each successful fetch
-//   - Marked inactive if feed becomes unavailable
+//   - Created through the createFeed GraphQL mutation
+//   - Referenced by ID from any number of DossierConfigs
+//   - Marked inactive to pause it everywhere without editing configs
 //
 // Example:
 //
@@ -216,18 +285,20 @@ func (a *StringArray) Scan(value interface{}) error {
 //	    URL:         "https://news.ycombinator.com/rss",
 //	    Title:       "Hacker News",
 //	    Description: "Links for the intellectually curious",
+//	    Category:    "tech",
 //	    Active:      true,
-//	    LastFetched: time.Now(),
 //	}
 type Feed struct {
-	ID          int       `json:"id" db:"id"`
-	URL         string    `json:"url" db:"url"`
-	Title       string    `json:"title" db:"title"`
-	Description string    `json:"description" db:"description"`
-	Active      bool      `json:"active" db:"active"`
-	LastFetched time.Time `json:"last_fetched" db:"last_fetched"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID          int        `json:"id" db:"id"`
+	URL         string     `json:"url" db:"url"`
+	Title       string     `json:"title" db:"title"`
+	Description string     `json:"description" db:"description"`
+	Category    string     `json:"category" db:"category"`
+	Priority    int        `json:"priority" db:"priority"`
+	Active      bool       `json:"active" db:"active"`
+	LastFetched *time.Time `json:"last_fetched" db:"last_fetched"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // ============================================================================
@@ -243,11 +314,16 @@ type Feed struct {
 // Field Descriptions:
 //   - ID: Unique identifier
 //   - FeedID: Reference to source Feed (0 if not tracked)
+//   - FeedURL: URL of the feed the article came from (used to divide slots between feeds)
 //   - Title: Article headline
 //   - Link: Canonical URL to full article
-//   - Description: Article excerpt or summary (from RSS)
-//   - Content: Full article text (if available in feed)
+//   - Description: Article excerpt or summary (from RSS), as plain text
+//   - DescriptionHTML: Sanitized HTML of the excerpt for display (empty if the feed sent plain text)
+//   - Content: Full article text (if available in feed), as plain text
 //   - Author: Article author name
+//   - MediaURL: Enclosure URL for podcast/audio/video items (empty if none)
+//   - MediaType: MIME type of the enclosure (e.g., "audio/mpeg")
+//   - Categories: Item categories/tags from the feed (used to spot sponsored items)
 //   - PublishedAt: Original publication timestamp from feed
 //   - CreatedAt: When article was fetched and stored
 //
@@ -257,6 +333,7 @@ type Feed struct {
 //   - Description: Usually present, may be empty
 //   - Content: Often empty (many feeds only provide excerpts)
 //   - Author: Often empty or generic
+//   - MediaURL: Present only for items with an <enclosure> (podcasts, video feeds)
 //   - PublishedAt: Usually accurate, but may be missing or incorrect
 //
 // Usage in Pipeline:
@@ -277,15 +354,20 @@ type Feed struct {
 //	    PublishedAt: time.Now().Add(-2 * time.Hour),
 //	}
 type Article struct {
-	ID          int       `json:"id" db:"id"`
-	FeedID      int       `json:"feed_id" db:"feed_id"`
-	Title       string    `json:"title" db:"title"`
-	Link        string    `json:"link" db:"link"`
-	Description string    `json:"description" db:"description"`
-	Content     string    `json:"content" db:"content"`
-	Author      string    `json:"author" db:"author"`
-	PublishedAt time.Time `json:"published_at" db:"published_at"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	ID              int       `json:"id" db:"id"`
+	FeedID          int       `json:"feed_id" db:"feed_id"`
+	FeedURL         string    `json:"feed_url,omitempty" db:"feed_url"`
+	Title           string    `json:"title" db:"title"`
+	Link            string    `json:"link" db:"link"`
+	Description     string    `json:"description" db:"description"`
+	DescriptionHTML string    `json:"description_html" db:"description_html"`
+	Content         string    `json:"content" db:"content"`
+	Author          string    `json:"author" db:"author"`
+	MediaURL        string    `json:"media_url" db:"media_url"`
+	MediaType       string    `json:"media_type" db:"media_type"`
+	Categories      []string  `json:"categories,omitempty" db:"categories"`
+	PublishedAt     time.Time `json:"published_at" db:"published_at"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 }
 
 // ============================================================================
@@ -305,6 +387,12 @@ type Article struct {
 //   - Summary: AI-generated HTML summary of articles
 //   - ArticleCount: Number of articles included
 //   - EmailSent: Whether email was successfully delivered
+//   - SkipReason: Why the run was skipped without sending (empty for real deliveries)
+//   - LLMCalls: Model calls the generation made
+//   - LLMTokens: Prompt plus response tokens across those calls (reported or estimated)
+//   - PagesScraped: Article pages requested during generation
+//   - DurationMS: Wall-clock time of the whole run (fetch, generate, send) in milliseconds
+//   - ErrorMessage: Why the run failed (empty for deliveries that didn't fail)
 //   - Articles: Populated list of articles (via SQL join, not in DB)
 //   - CreatedAt: Record creation timestamp
 //
@@ -316,7 +404,7 @@ type Article struct {
 // Query Patterns:
 //   - List deliveries for specific config: WHERE config_id = ?
 //   - Recent deliveries: ORDER BY delivery_date DESC LIMIT 10
-//   - Failed deliveries: WHERE email_sent = false
+//   - Failed deliveries: WHERE error_message IS NOT NULL
 //
 // Example:
 //
@@ -334,6 +422,12 @@ type DossierDelivery struct {
 	Summary      string    `json:"summary" db:"summary"`
 	ArticleCount int       `json:"article_count" db:"article_count"`
 	EmailSent    bool      `json:"email_sent" db:"email_sent"`
+	SkipReason   string    `json:"skip_reason" db:"skip_reason"`
+	LLMCalls     int       `json:"llm_calls" db:"llm_calls"`
+	LLMTokens    int       `json:"llm_tokens" db:"llm_tokens"`
+	PagesScraped int       `json:"pages_scraped" db:"pages_scraped"`
+	DurationMS   int64     `json:"duration_ms" db:"duration_ms"`
+	ErrorMessage string    `json:"error_message" db:"error_message"`
 	Articles     []Article `json:"articles"` // Populated via join, not stored in this table
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 }
@@ -422,3 +516,27 @@ type Tone struct {
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
 }
+
+// ============================================================================
+// SERVER SETTINGS
+// ============================================================================
+
+// Setting is one effective server setting, as reported by the admin-only
+// serverConfig query. Each package reports the values it actually uses, so a
+// setting still at its default (say SMTP_HOST left as "localhost" because the
+// environment variable never loaded) is easy to spot.
+//
+// Field Descriptions:
+//   - Name: Environment variable that controls the setting (e.g., "SMTP_HOST"),
+//     or a plain description for fixed settings
+//   - Value: Effective value, with passwords in URLs masked (empty for secrets)
+//   - Secret: Whether the value must never be shown; the serverConfig query
+//     reports only whether the variable is set
+//
+// Whether a setting came from the environment is worked out by the
+// serverConfig resolver, which reads the environment itself.
+type Setting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret"`
+}
@@ -0,0 +1,120 @@
+// Package outbound configures HTTP transports for requests leaving the server.
+//
+// Every outbound client (feed fetching, article scraping, and the Ollama API)
+// builds its transport here so proxy settings are applied consistently.
+//
+// # Proxy Selection
+//
+//   - OUTBOUND_PROXY: When set, used for both http and https requests
+//   - HTTP_PROXY / HTTPS_PROXY: Standard variables, used when OUTBOUND_PROXY
+//     is not set (same semantics as http.ProxyFromEnvironment)
+//   - NO_PROXY: Comma-separated hosts that bypass the proxy in both cases
+//
+// Requests to localhost and loopback addresses never use a proxy, so a local
+// Ollama keeps working when a proxy is configured for external traffic.
+package outbound
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+var (
+	proxyOnce   sync.Once
+	proxyFunc   func(*url.URL) (*url.URL, error)
+	proxyConfig *httpproxy.Config
+)
+
+// loadProxyConfig reads proxy settings from the environment once.
+func loadProxyConfig() {
+	proxyOnce.Do(func() {
+		config := httpproxy.FromEnvironment()
+		if explicit := strings.TrimSpace(os.Getenv("OUTBOUND_PROXY")); explicit != "" {
+			config.HTTPProxy = explicit
+			config.HTTPSProxy = explicit
+		}
+		proxyConfig = config
+		proxyFunc = config.ProxyFunc()
+	})
+}
+
+// Proxy returns the proxy URL for a request, or nil for a direct connection.
+// It has the signature expected by http.Transport.Proxy.
+//
+// Parameters:
+//   - req: Outgoing request
+//
+// Returns:
+//   - *url.URL: Proxy to use (nil for none)
+//   - error: Invalid proxy configuration
+func Proxy(req *http.Request) (*url.URL, error) {
+	loadProxyConfig()
+	return proxyFunc(req.URL)
+}
+
+// NewTransport returns a clone of http.DefaultTransport that uses Proxy.
+//
+// Callers may further customize the returned transport (e.g. DialContext).
+//
+// Returns:
+//   - *http.Transport: Transport with outbound proxy support
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = Proxy
+	return transport
+}
+
+// IsProxyAddress reports whether a dial address ("host:port") is one of the
+// configured proxies.
+//
+// Dialers that restrict destinations (such as the scraper's private address
+// block) use this to let connections to an internal corporate proxy through;
+// the proxied target itself is still validated before the request is made.
+//
+// Parameters:
+//   - address: Address passed to DialContext
+//
+// Returns:
+//   - bool: true if address is a configured proxy
+func IsProxyAddress(address string) bool {
+	loadProxyConfig()
+	for _, raw := range []string{proxyConfig.HTTPProxy, proxyConfig.HTTPSProxy} {
+		if raw == "" {
+			continue
+		}
+		proxyURL, err := url.Parse(raw)
+		if err != nil || proxyURL.Host == "" {
+			// httpproxy also accepts bare "host:port"
+			proxyURL, err = url.Parse("http://" + raw)
+			if err != nil {
+				continue
+			}
+		}
+		if proxyHostPort(proxyURL) == address {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyHostPort returns the address a transport dials for a proxy URL,
+// filling in the scheme's default port.
+func proxyHostPort(proxyURL *url.URL) string {
+	if proxyURL.Port() != "" {
+		return proxyURL.Host
+	}
+	port := "80"
+	switch proxyURL.Scheme {
+	case "https":
+		port = "443"
+	case "socks5", "socks5h":
+		port = "1080"
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), port)
+}
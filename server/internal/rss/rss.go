@@ -19,7 +19,7 @@
 //  3. Extract: Convert feed items to Article models
 //  4. Normalize: Handle missing/optional fields with sensible defaults
 //  5. Aggregate: Combine articles from multiple feeds
-//  6. Sort: Order by publication date (newest first)
+//  6. Sort: Order by publication date (newest first, ties by link)
 //  7. Limit: Return requested number of articles
 //
 // # Data Quality Handling
@@ -30,6 +30,9 @@
 //   - Missing timestamps → Use current time
 //   - Missing content → Fall back to description
 //   - Missing author → Use empty string
+//   - Double-encoded entities ("AT&amp;T") → Decoded in plain-text fields
+//   - HTML/XHTML descriptions and content → Plain text for prompts, with a
+//     sanitized HTML copy of the description kept for display
 //   - Parse failures → Skip feed, continue with others
 //
 // # Integration Points
@@ -55,20 +58,108 @@
 package rss
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"html"
+	"io"
 	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/geraldfingburke/dossier/server/internal/ai"
 	"github.com/geraldfingburke/dossier/server/internal/models"
+	"github.com/geraldfingburke/dossier/server/internal/outbound"
+	"github.com/geraldfingburke/dossier/server/internal/textutil"
 	"github.com/mmcdole/gofeed"
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // ============================================================================
 // SERVICE DEFINITION
 // ============================================================================
 
+const (
+	// maxEntityDecodePasses bounds repeated entity decoding for feeds that
+	// double-encode (e.g. "&amp;#8217;" → "&#8217;" → "’")
+	maxEntityDecodePasses = 3
+
+	// defaultFetchAttempts is how many times a feed is requested when
+	// RSS_FETCH_ATTEMPTS is not set (1 initial attempt + 2 retries)
+	defaultFetchAttempts = 3
+
+	// defaultFetchTimeout bounds each feed request attempt when
+	// RSS_FETCH_TIMEOUT is not set
+	defaultFetchTimeout = 30 * time.Second
+
+	// fetchRetryBaseDelay is the wait before the first retry; it doubles for
+	// each subsequent retry
+	fetchRetryBaseDelay = time.Second
+
+	// defaultMaxBodyBytes caps a feed response body when RSS_MAX_BODY_BYTES
+	// is not set; real feeds are rarely more than a few hundred kilobytes
+	defaultMaxBodyBytes = 10 << 20
+
+	// defaultFetchConcurrency is how many of one aggregation's feeds are
+	// fetched at once when RSS_FETCH_CONCURRENCY is not set
+	defaultFetchConcurrency = 4
+)
+
+// Sponsored item handling (dossier_configs.sponsored_filter).
+const (
+	SponsoredDrop   = "drop"   // Remove sponsored items before selection
+	SponsoredDemote = "demote" // Keep them, after every other item
+	SponsoredOff    = "off"    // Treat them like any other item
+)
+
+// Division of a dossier's article_count between its feeds
+// (dossier_configs.distribution, see Distribute).
+const (
+	DistributionEven     = "even"     // Every feed gets an equal share
+	DistributionPriority = "priority" // Shares follow each shared feed's priority
+	DistributionNewest   = "newest"   // The newest articles win, whatever their feed
+)
+
+// sponsoredCategoryPrefix marks a sponsored pattern that matches an item's
+// categories rather than its title.
+const sponsoredCategoryPrefix = "category:"
+
+// DefaultSponsoredPatterns mark sponsored items when neither the
+// configuration nor SPONSORED_PATTERNS sets patterns. Title patterns are
+// bracketed or phrased so an ordinary headline such as "NASA-sponsored
+// mission" doesn't match; the bare words are only trusted as categories.
+var DefaultSponsoredPatterns = []string{
+	"[sponsored]", "(sponsored)", "sponsored:", "sponsored content", "sponsored post",
+	"[ad]", "(ad)", "advertorial", "paid post", "partner content", "paid content",
+	"category:sponsored", "category:sponsored content", "category:advertising",
+	"category:advertorial", "category:ad", "category:ads", "category:partner content",
+	"category:paid post", "category:promoted",
+}
+
+// ErrNoArticles is returned when a configuration's feeds yield no articles,
+// e.g. a quiet news day or every feed failing. Clients can report it as
+// "nothing to send" rather than a generation failure.
+var ErrNoArticles = errors.New("no articles found from the configured feeds")
+
+// ErrTooFewArticles is returned when fewer articles than a configuration's
+// min_articles were found, so nothing is sent.
+var ErrTooFewArticles = errors.New("too few articles to send")
+
+// htmlTagPattern detects markup in feed text; such text is left untouched by
+// entity decoding because its entities are legitimately part of the HTML.
+var htmlTagPattern = regexp.MustCompile(`<[a-zA-Z/!][^>]*>`)
+
 // Service handles RSS feed operations and article aggregation.
 //
 // The service maintains a gofeed parser instance that is reused across
@@ -82,9 +173,21 @@ import (
 // Fields:
 //   - parser: gofeed parser instance (reused for efficiency)
 //   - aiService: AI service reference (for potential future enhancements)
+//   - fetchAttempts: Attempts per feed for transient failures (RSS_FETCH_ATTEMPTS)
+//   - fetchTimeout: Timeout for each feed request attempt (RSS_FETCH_TIMEOUT)
+//   - maxBodyBytes: Largest feed response body accepted (RSS_MAX_BODY_BYTES)
+//   - fetchConcurrency: Feeds fetched at once by FetchArticlesFromFeeds (RSS_FETCH_CONCURRENCY)
+//   - sponsoredPatterns: Default sponsored patterns (SPONSORED_PATTERNS)
 type Service struct {
 	parser    *gofeed.Parser
 	aiService *ai.Service
+
+	fetchAttempts    int
+	fetchTimeout     time.Duration
+	maxBodyBytes     int64
+	fetchConcurrency int
+
+	sponsoredPatterns []string
 }
 
 // ============================================================================
@@ -94,7 +197,21 @@ type Service struct {
 // NewService creates a new RSS service with feed parsing capabilities.
 //
 // The service initializes a gofeed parser that automatically detects
-// and handles RSS 1.0, RSS 2.0, and Atom feed formats.
+// and handles RSS 1.0, RSS 2.0, and Atom feed formats. Feed requests go
+// through the outbound transport, so OUTBOUND_PROXY / HTTP_PROXY apply.
+//
+// Environment Variables:
+//   - RSS_FETCH_ATTEMPTS: Total attempts per feed when a request fails with a
+//     network error or 5xx/429 response (default: 3; 1 disables retries)
+//   - RSS_FETCH_TIMEOUT: Timeout for each feed request attempt, as a Go
+//     duration (default: "30s")
+//   - RSS_MAX_BODY_BYTES: Largest feed response body, in bytes, read before
+//     parsing; larger feeds are skipped (default: 10485760, i.e. 10 MiB)
+//   - RSS_FETCH_CONCURRENCY: How many feeds FetchArticlesFromFeeds fetches at
+//     once (default: 4; 1 fetches sequentially)
+//   - SPONSORED_PATTERNS: Comma-separated patterns marking sponsored items,
+//     replacing DefaultSponsoredPatterns for configurations that set none
+//     (default: DefaultSponsoredPatterns)
 //
 // Parameters:
 //   - aiService: AI service for potential article intelligence features
@@ -107,9 +224,71 @@ type Service struct {
 //	rssService := rss.NewService(aiService)
 //	articles, err := rssService.FetchArticlesFromFeeds(ctx, feedURLs, 10)
 func NewService(aiService *ai.Service) *Service {
+	parser := gofeed.NewParser()
+	parser.Client = &http.Client{Transport: outbound.NewTransport()}
+
+	fetchAttempts := defaultFetchAttempts
+	if value := os.Getenv("RSS_FETCH_ATTEMPTS"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 1 {
+			fetchAttempts = parsed
+		} else {
+			log.Printf("Invalid RSS_FETCH_ATTEMPTS %q, using default %d", value, defaultFetchAttempts)
+		}
+	}
+
+	fetchTimeout := defaultFetchTimeout
+	if value := os.Getenv("RSS_FETCH_TIMEOUT"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			fetchTimeout = parsed
+		} else {
+			log.Printf("Invalid RSS_FETCH_TIMEOUT %q, using default %s", value, defaultFetchTimeout)
+		}
+	}
+
+	maxBodyBytes := int64(defaultMaxBodyBytes)
+	if value := os.Getenv("RSS_MAX_BODY_BYTES"); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			maxBodyBytes = parsed
+		} else {
+			log.Printf("Invalid RSS_MAX_BODY_BYTES %q, using default %d", value, defaultMaxBodyBytes)
+		}
+	}
+
+	fetchConcurrency := defaultFetchConcurrency
+	if value := os.Getenv("RSS_FETCH_CONCURRENCY"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 1 {
+			fetchConcurrency = parsed
+		} else {
+			log.Printf("Invalid RSS_FETCH_CONCURRENCY %q, using default %d", value, defaultFetchConcurrency)
+		}
+	}
+
+	sponsoredPatterns := DefaultSponsoredPatterns
+	if value := os.Getenv("SPONSORED_PATTERNS"); value != "" {
+		var patterns []string
+		for _, pattern := range strings.Split(value, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern == "" {
+				continue
+			}
+			if err := CheckSponsoredPattern(pattern); err != nil {
+				log.Printf("Invalid SPONSORED_PATTERNS entry %q, ignoring it: %v", pattern, err)
+				continue
+			}
+			patterns = append(patterns, pattern)
+		}
+		sponsoredPatterns = patterns
+	}
+
 	return &Service{
-		parser:    gofeed.NewParser(),
+		parser:    parser,
 		aiService: aiService,
+
+		fetchAttempts:    fetchAttempts,
+		fetchTimeout:     fetchTimeout,
+		maxBodyBytes:     maxBodyBytes,
+		fetchConcurrency: fetchConcurrency,
+
+		sponsoredPatterns: sponsoredPatterns,
 	}
 }
 
@@ -117,6 +296,20 @@ func NewService(aiService *ai.Service) *Service {
 // FEED FETCHING OPERATIONS
 // ============================================================================
 
+// Settings reports the service's effective configuration for the
+// serverConfig query.
+//
+// Returns:
+//   - []models.Setting: Effective values
+func (s *Service) Settings() []models.Setting {
+	return []models.Setting{
+		{Name: "RSS_FETCH_TIMEOUT", Value: s.fetchTimeout.String()},
+		{Name: "RSS_FETCH_ATTEMPTS", Value: strconv.Itoa(s.fetchAttempts)},
+		{Name: "RSS_FETCH_CONCURRENCY", Value: strconv.Itoa(s.fetchConcurrency)},
+		{Name: "RSS_MAX_BODY_BYTES", Value: strconv.FormatInt(s.maxBodyBytes, 10)},
+	}
+}
+
 // FetchFeed fetches and parses a single RSS/Atom feed from a URL.
 //
 // This method handles the complete process of downloading and parsing
@@ -130,7 +323,26 @@ func NewService(aiService *ai.Service) *Service {
 //
 // Context Support:
 // The method respects context cancellation, allowing timeouts and
-// cancellation of long-running feed fetches.
+// cancellation of long-running feed fetches. Each attempt is additionally
+// bounded by RSS_FETCH_TIMEOUT.
+//
+// Content Negotiation:
+// Requests send an Accept header preferring feed media types, since some
+// servers answer 406 without one. The body is sniffed rather than trusting
+// Content-Type: XML that fails to parse is retried once after stripping a
+// BOM, leading junk, and invalid control characters, and an HTML page is
+// searched for a <link rel="alternate"> feed, which is fetched instead.
+//
+// Hostile Feeds:
+// Bodies larger than RSS_MAX_BODY_BYTES are rejected without being parsed,
+// and a panic inside the parser is recovered and reported as an ordinary
+// parse error, so one broken feed cannot take down a whole run.
+//
+// Retries:
+// Network errors (DNS, connection resets, timeouts) and 5xx/429 responses are
+// retried up to RSS_FETCH_ATTEMPTS total attempts with exponential backoff
+// (1s, 2s, 4s, ...). Other HTTP errors and parse errors fail immediately;
+// a malformed feed will not parse any better on the next try.
 //
 // Error Conditions:
 //   - Network failures (DNS, connection timeout, etc.)
@@ -157,11 +369,279 @@ func NewService(aiService *ai.Service) *Service {
 //	}
 //	log.Printf("Fetched %d items from %s", len(feed.Items), feed.Title)
 func (s *Service) FetchFeed(ctx context.Context, feedURL string) (*gofeed.Feed, error) {
-	feed, err := s.parser.ParseURLWithContext(feedURL, ctx)
+	var err error
+	for attempt := 1; attempt <= s.fetchAttempts; attempt++ {
+		var feed *gofeed.Feed
+		feed, err = s.fetchFeedOnce(ctx, feedURL)
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("Fetched feed %s on attempt %d/%d", feedURL, attempt, s.fetchAttempts)
+			}
+			return feed, nil
+		}
+
+		if !isRetryableFetchError(ctx, err) {
+			break
+		}
+		if attempt == s.fetchAttempts {
+			log.Printf("Feed %s attempt %d/%d failed: %v (giving up)", feedURL, attempt, s.fetchAttempts, err)
+			break
+		}
+
+		delay := fetchRetryBaseDelay << (attempt - 1)
+		log.Printf("Feed %s attempt %d/%d failed: %v (retrying in %s)", feedURL, attempt, s.fetchAttempts, err, delay)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("error fetching feed: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+	return nil, fmt.Errorf("error parsing feed: %w", err)
+}
+
+// fetchFeedOnce performs a single feed request bounded by the per-feed timeout.
+func (s *Service) fetchFeedOnce(ctx context.Context, feedURL string) (*gofeed.Feed, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, s.fetchTimeout)
+	defer cancel()
+
+	body, err := s.downloadFeed(attemptCtx, feedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.parseFeedBody(body)
+	if err == nil || !looksLikeHTML(body) {
+		return feed, err
+	}
+
+	// An HTML page rather than a feed: follow its <link rel="alternate">
+	// once, without further discovery on the result
+	discovered := discoverFeedURL(body, feedURL)
+	if discovered == "" {
+		return nil, fmt.Errorf("response is an HTML page with no feed link: %w", err)
+	}
+	log.Printf("Feed %s is an HTML page; using discovered feed %s", feedURL, discovered)
+
+	body, err = s.downloadFeed(attemptCtx, discovered)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing feed: %w", err)
+		return nil, err
 	}
-	return feed, nil
+	return s.parseFeedBody(body)
+}
+
+// feedAcceptHeader prefers feed media types but accepts anything, since many
+// servers label feeds text/html or text/plain and some 406 without it.
+const feedAcceptHeader = "application/rss+xml, application/atom+xml, application/feed+json, " +
+	"application/xml;q=0.9, text/xml;q=0.9, */*;q=0.8"
+
+// downloadFeed GETs a feed URL and returns the response body.
+//
+// Non-2xx responses are returned as gofeed.HTTPError so the retry policy
+// classifies them the same way it did when gofeed made the request. At most
+// RSS_MAX_BODY_BYTES are read; a larger body is an error rather than being
+// truncated, since a cut-off document would not parse anyway.
+//
+// Parameters:
+//   - ctx: Per-attempt context
+//   - feedURL: URL to request
+//
+// Returns:
+//   - []byte: Response body
+//   - error: Request, HTTP status, size limit, or read error
+func (s *Service) downloadFeed(ctx context.Context, feedURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", s.parser.UserAgent)
+	req.Header.Set("Accept", feedAcceptHeader)
+
+	resp, err := s.parser.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	// Read one byte past the limit to tell "exactly at" from "over"
+	body, err := io.ReadAll(io.LimitReader(resp.Body, s.maxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > s.maxBodyBytes {
+		return nil, fmt.Errorf("feed body exceeds RSS_MAX_BODY_BYTES (%d bytes)", s.maxBodyBytes)
+	}
+	return body, nil
+}
+
+// parseFeedBody parses a downloaded feed, retrying once on a cleaned copy of
+// the bytes when the body is XML that gofeed rejected as-is.
+//
+// The retry covers feeds served with a byte-order mark, whitespace or junk
+// before the XML declaration, or stray control characters, all of which
+// are common and all of which the XML decoder refuses outright.
+//
+// Parameters:
+//   - body: Raw response body
+//
+// Returns:
+//   - *gofeed.Feed: Parsed feed
+//   - error: Parse error from the first attempt if the retry also fails
+func (s *Service) parseFeedBody(body []byte) (*gofeed.Feed, error) {
+	feed, err := s.safeParse(body)
+	if err == nil || !looksLikeXML(body) {
+		return feed, err
+	}
+
+	cleaned := cleanFeedXML(body)
+	if retried, retryErr := s.safeParse(cleaned); retryErr == nil {
+		return retried, nil
+	}
+	return nil, err
+}
+
+// safeParse runs the gofeed parser, converting a parser panic on malformed
+// input into an error so the feed is skipped instead of crashing the caller.
+func (s *Service) safeParse(body []byte) (feed *gofeed.Feed, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			feed = nil
+			err = fmt.Errorf("feed parser panicked: %v", recovered)
+		}
+	}()
+	return s.parser.Parse(bytes.NewReader(body))
+}
+
+// ============================================================================
+// FEED CONTENT SNIFFING
+// ============================================================================
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// sniffPrefix returns the start of a body with any BOM and leading
+// whitespace removed, lower-cased for prefix checks.
+func sniffPrefix(body []byte) []byte {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(body, utf8BOM))
+	if len(trimmed) > 512 {
+		trimmed = trimmed[:512]
+	}
+	return bytes.ToLower(trimmed)
+}
+
+// looksLikeXML reports whether a body starts like an XML feed, regardless of
+// the Content-Type the server sent.
+func looksLikeXML(body []byte) bool {
+	prefix := sniffPrefix(body)
+	for _, start := range []string{"<?xml", "<rss", "<feed", "<rdf:rdf"} {
+		if bytes.HasPrefix(prefix, []byte(start)) {
+			return true
+		}
+	}
+	// Junk before the declaration still counts if a feed root follows
+	return bytes.Contains(prefix, []byte("<?xml")) || bytes.Contains(prefix, []byte("<rss"))
+}
+
+// looksLikeHTML reports whether a body is an HTML document.
+func looksLikeHTML(body []byte) bool {
+	prefix := sniffPrefix(body)
+	return bytes.HasPrefix(prefix, []byte("<!doctype html")) || bytes.Contains(prefix, []byte("<html"))
+}
+
+// cleanFeedXML strips the problems that stop an otherwise valid feed from
+// decoding: a BOM, anything before the first '<', and control characters
+// that are not allowed in XML 1.0.
+func cleanFeedXML(body []byte) []byte {
+	body = bytes.TrimPrefix(body, utf8BOM)
+	if i := bytes.IndexByte(body, '<'); i > 0 {
+		body = body[i:]
+	}
+	return bytes.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, body)
+}
+
+// discoverFeedURL finds the first RSS/Atom/JSON feed advertised by an HTML
+// page through <link rel="alternate">, resolved against the page URL.
+//
+// Parameters:
+//   - body: HTML page
+//   - pageURL: URL the page was fetched from
+//
+// Returns:
+//   - string: Absolute feed URL, or "" if none is advertised
+func discoverFeedURL(body []byte, pageURL string) string {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+
+	var found string
+	doc.Find("link[rel~='alternate'][href]").EachWithBreak(func(_ int, link *goquery.Selection) bool {
+		switch strings.ToLower(strings.TrimSpace(link.AttrOr("type", ""))) {
+		case "application/rss+xml", "application/atom+xml", "application/feed+json", "application/json":
+		default:
+			return true
+		}
+		href, err := url.Parse(strings.TrimSpace(link.AttrOr("href", "")))
+		if err != nil {
+			return true
+		}
+		resolved := base.ResolveReference(href)
+		if resolved.Scheme != "http" && resolved.Scheme != "https" {
+			return true
+		}
+		found = resolved.String()
+		return false
+	})
+	return found
+}
+
+// isRetryableFetchError reports whether a feed fetch failure is likely
+// transient: a network error or timeout, or a 5xx/429 response. Parse errors
+// and other HTTP statuses are permanent. Nothing is retried once the caller's
+// context is done.
+//
+// Parameters:
+//   - ctx: Caller's context (not the per-attempt one)
+//   - err: Error from a fetch attempt
+//
+// Returns:
+//   - bool: true if the fetch should be retried
+func isRetryableFetchError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr gofeed.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	// The client wraps transport failures in *url.Error, which itself
+	// satisfies net.Error; unwrap it so request-construction errors such as
+	// an unsupported scheme are not mistaken for network trouble
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	// Per-attempt timeouts and connections dropped mid-response
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
 }
 
 // ============================================================================
@@ -177,7 +657,8 @@ func (s *Service) FetchFeed(ctx context.Context, feedURL string) (*gofeed.Feed,
 //
 // Algorithm:
 //  1. Calculate articles per feed (maxArticles / number of feeds)
-//  2. Fetch each feed in sequence (continues on individual failures)
+//  2. Fetch the feeds, RSS_FETCH_CONCURRENCY at a time (continues on
+//     individual failures)
 //  3. Convert feed items to Article models
 //  4. Normalize missing/optional fields
 //  5. Aggregate all articles into single collection
@@ -185,9 +666,10 @@ func (s *Service) FetchFeed(ctx context.Context, feedURL string) (*gofeed.Feed,
 //  7. Limit to maxArticles total
 //
 // Distribution Strategy:
-// Articles are distributed evenly across feeds, but if some feeds return
-// fewer articles than allocated, other feeds can fill the gap. This ensures
-// the requested article count is reached when possible.
+// Each feed contributes at most an even share of maxArticles (its first
+// items, usually the newest); the rest of a large feed is dropped. How a
+// dossier's article_count is then divided between the feeds is decided by
+// Distribute.
 //
 // Error Handling:
 // Individual feed failures are logged but don't stop processing. The method
@@ -195,17 +677,22 @@ func (s *Service) FetchFeed(ctx context.Context, feedURL string) (*gofeed.Feed,
 // succeeds. Returns error only if ALL feeds fail or other critical issues occur.
 //
 // Field Normalization:
+//   - Title/Description/Content: HTML entities decoded (plain text only)
 //   - PublishedAt: Uses item.PublishedParsed or current time if missing
 //   - Content: Uses item.Content or falls back to item.Description
 //   - Author: Uses item.Author.Name or empty string if missing
+//   - MediaURL/MediaType: First audio/video enclosure (podcasts)
+//   - Link: Falls back to the enclosure URL for media-only items
 //   - Description: Uses item.Description (may be empty)
 //   - Title: Always present (required by RSS spec)
 //   - Link: Always present (required by RSS spec)
 //
 // Performance Considerations:
-//   - Feeds are fetched sequentially (not parallel) to avoid overwhelming servers
-//   - Uses bubble sort for simplicity (article counts typically < 100)
-//   - Each feed fetch respects the context timeout
+//   - Up to RSS_FETCH_CONCURRENCY feeds are fetched at once, so one slow feed
+//     doesn't hold up the rest; feeds are usually on different hosts
+//   - Each feed fetch respects the context timeout. Feeds not finished (or
+//     not started) when ctx ends are skipped, and the articles from the feeds
+//     that did finish are returned
 //
 // Parameters:
 //   - ctx: Context for timeout and cancellation
@@ -213,7 +700,7 @@ func (s *Service) FetchFeed(ctx context.Context, feedURL string) (*gofeed.Feed,
 //   - maxArticles: Maximum total articles to return across all feeds
 //
 // Returns:
-//   - []models.Article: Aggregated articles sorted by date (newest first)
+//   - []models.Article: Aggregated articles in SortArticles order
 //   - error: Only if ALL feeds fail or critical error occurs
 //
 // Example:
@@ -229,7 +716,9 @@ func (s *Service) FetchFeed(ctx context.Context, feedURL string) (*gofeed.Feed,
 //	}
 //	log.Printf("Fetched %d articles from %d feeds", len(articles), len(feedURLs))
 func (s *Service) FetchArticlesFromFeeds(ctx context.Context, feedURLs []string, maxArticles int) ([]models.Article, error) {
-	var allArticles []models.Article
+	if len(feedURLs) == 0 {
+		return nil, nil
+	}
 
 	// Calculate target articles per feed for even distribution
 	articlesPerFeed := maxArticles / len(feedURLs)
@@ -237,75 +726,584 @@ func (s *Service) FetchArticlesFromFeeds(ctx context.Context, feedURLs []string,
 		articlesPerFeed = 1
 	}
 
-	// Fetch articles from each feed
-	for _, feedURL := range feedURLs {
-		log.Printf("Fetching articles from feed: %s", feedURL)
-
-		// Fetch and parse feed
-		feed, err := s.FetchFeed(ctx, feedURL)
-		if err != nil {
-			log.Printf("Error fetching feed %s: %v", feedURL, err)
-			continue // Skip failed feeds, continue with others
-		}
-
-		// Convert feed items to Article models
-		feedArticles := make([]models.Article, 0)
-		for i, item := range feed.Items {
-			// Limit articles per feed
-			if i >= articlesPerFeed {
-				break
+	// Fetch the feeds concurrently; each goroutine fills its own slot so
+	// no locking is needed
+	perFeed := make([][]models.Article, len(feedURLs))
+	slots := make(chan struct{}, s.fetchConcurrency)
+	var wg sync.WaitGroup
+	for i, feedURL := range feedURLs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				log.Printf("Skipping feed %s: %v", feedURL, ctx.Err())
+				return
 			}
 
-			// Normalize published date (use current time if missing)
-			publishedAt := time.Now()
-			if item.PublishedParsed != nil {
-				publishedAt = *item.PublishedParsed
+			log.Printf("Fetching articles from feed: %s", feedURL)
+			feed, err := s.FetchFeed(ctx, feedURL)
+			if err != nil {
+				log.Printf("Error fetching feed %s: %v", feedURL, err)
+				return // Skip failed feeds, continue with others
 			}
 
-			// Normalize content (prefer full content, fall back to description)
-			content := item.Content
-			if content == "" {
-				content = item.Description
+			// Convert feed items to Article models, up to this feed's share
+			items := feed.Items
+			if len(items) > articlesPerFeed {
+				items = items[:articlesPerFeed]
+			}
+			feedArticles := make([]models.Article, 0, len(items))
+			for _, item := range items {
+				article := articleFromItem(item)
+				article.FeedURL = feedURL
+				feedArticles = append(feedArticles, article)
 			}
+			perFeed[i] = feedArticles
+			log.Printf("Fetched %d of %d articles from %s", len(feedArticles), len(feed.Items), feedURL)
+		}()
+	}
+	wg.Wait()
+
+	var allArticles []models.Article
+	for _, feedArticles := range perFeed {
+		allArticles = append(allArticles, feedArticles...)
+	}
+
+	// Always sort, not only when trimming, so the order never depends on
+	// which feed was fetched first; then limit to maxArticles
+	SortArticles(allArticles)
+	if len(allArticles) > maxArticles {
+		allArticles = allArticles[:maxArticles]
+	}
+
+	log.Printf("Total articles fetched: %d", len(allArticles))
+	return allArticles, nil
+}
+
+// FetchFeedArticles fetches one feed and converts every item to an Article,
+// normalized exactly as FetchArticlesFromFeeds does. Used by the scheduler's
+// background pre-fetcher, which stores whole feeds rather than a per-feed
+// share of an article count.
+//
+// Parameters:
+//   - ctx: Context for timeout and cancellation
+//   - feedURL: RSS/Atom feed URL
+//
+// Returns:
+//   - []models.Article: The feed's items in feed order
+//   - error: Fetch or parse error from FetchFeed
+func (s *Service) FetchFeedArticles(ctx context.Context, feedURL string) ([]models.Article, error) {
+	feed, err := s.FetchFeed(ctx, feedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]models.Article, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		article := articleFromItem(item)
+		article.FeedURL = feedURL
+		articles = append(articles, article)
+	}
+	return articles, nil
+}
+
+// articleFromItem converts a parsed feed item to an Article (see the Field
+// Normalization notes on FetchArticlesFromFeeds).
+func articleFromItem(item *gofeed.Item) models.Article {
+	// Normalize published date (use current time if missing)
+	publishedAt := time.Now()
+	if item.PublishedParsed != nil {
+		publishedAt = *item.PublishedParsed
+	}
+
+	// Normalize content (prefer full content, fall back to description)
+	content := item.Content
+	if content == "" {
+		content = item.Description
+	}
+
+	// Extract author name (may be empty)
+	author := ""
+	if item.Author != nil {
+		author = item.Author.Name
+	}
+
+	// Extract podcast/video enclosure (may be empty)
+	mediaURL, mediaType := MediaEnclosure(item)
+
+	// Build normalized article model; HTML (Atom xhtml, CDATA) becomes
+	// plain text, with the description's sanitized HTML kept for display
+	description, descriptionHTML := NormalizeItemText(item.Description)
+	contentText, _ := NormalizeItemText(content)
+	article := models.Article{
+		Title:           DecodeEntities(item.Title),
+		Link:            item.Link,
+		Description:     description,
+		DescriptionHTML: descriptionHTML,
+		Content:         contentText,
+		Author:          author,
+		MediaURL:        mediaURL,
+		MediaType:       mediaType,
+		Categories:      item.Categories,
+		PublishedAt:     publishedAt,
+	}
+
+	// Media-only items (common in podcasts) link to the enclosure itself
+	if article.Link == "" {
+		article.Link = mediaURL
+	}
+	return article
+}
+
+// SortArticles orders articles newest first, breaking ties by link, so the
+// same set of articles always comes out in the same order whatever order the
+// feeds were fetched in. Generation, caching, and delivery keys built from the
+// article list then see a stable sequence.
+//
+// Parameters:
+//   - articles: Articles to sort in place
+func SortArticles(articles []models.Article) {
+	sort.SliceStable(articles, func(i, j int) bool {
+		if !articles[i].PublishedAt.Equal(articles[j].PublishedAt) {
+			return articles[i].PublishedAt.After(articles[j].PublishedAt)
+		}
+		return articles[i].Link < articles[j].Link
+	})
+}
+
+// PublishedAfter drops articles published at or before cutoff. A zero cutoff
+// keeps every article.
+//
+// Parameters:
+//   - articles: Aggregated articles
+//   - cutoff: Publication time an article must be after (zero for no cutoff)
+//
+// Returns:
+//   - []models.Article: Articles published after cutoff, in their original order
+func PublishedAfter(articles []models.Article, cutoff time.Time) []models.Article {
+	if cutoff.IsZero() {
+		return articles
+	}
+
+	kept := make([]models.Article, 0, len(articles))
+	for _, article := range articles {
+		if article.PublishedAt.After(cutoff) {
+			kept = append(kept, article)
+		}
+	}
+	return kept
+}
+
+// CapPerSource limits how many articles any single source domain contributes.
+//
+// Articles keep their existing order, so the first maxPerSource articles from
+// each domain (the newest, when the input is sorted by date) are kept and
+// the rest dropped. Domains come from textutil.ExtractDomain, so
+// "www.example.com" and "example.com" count as the same source. Articles
+// without a recognizable domain are never capped.
+//
+// Parameters:
+//   - articles: Aggregated articles
+//   - maxPerSource: Per-domain limit (0 or less disables the cap)
+//
+// Returns:
+//   - []models.Article: Articles within the cap
+func CapPerSource(articles []models.Article, maxPerSource int) []models.Article {
+	if maxPerSource <= 0 {
+		return articles
+	}
 
-			// Extract author name (may be empty)
-			author := ""
-			if item.Author != nil {
-				author = item.Author.Name
+	counts := make(map[string]int)
+	capped := make([]models.Article, 0, len(articles))
+	dropped := 0
+	for _, article := range articles {
+		domain := textutil.ExtractDomain(article.Link)
+		if domain != "" {
+			if counts[domain] >= maxPerSource {
+				dropped++
+				continue
 			}
+			counts[domain]++
+		}
+		capped = append(capped, article)
+	}
+
+	if dropped > 0 {
+		log.Printf("Dropped %d articles over the per-source cap of %d", dropped, maxPerSource)
+	}
+	return capped
+}
+
+// FilterSponsored drops or demotes sponsored and ad-labeled items according
+// to the configuration's sponsored_filter, before selection and
+// summarization see them.
+//
+// Patterns:
+// The configuration's sponsored_patterns, or the server defaults
+// (SPONSORED_PATTERNS, else DefaultSponsoredPatterns) when it has none. A
+// pattern matches case-insensitively: "category:<name>" against the item's
+// categories exactly, anything else as a substring of its title.
+//
+// Modes:
+//   - drop: Sponsored items are removed
+//   - demote: Sponsored items move after every other item (keeping their
+//     order), so they only fill slots nothing else would
+//   - off: Articles are returned unchanged
+//
+// Parameters:
+//   - articles: Aggregated articles
+//   - config: Configuration whose filter and patterns apply
+//
+// Returns:
+//   - []models.Article: Filtered or reordered articles
+func (s *Service) FilterSponsored(articles []models.Article, config *models.DossierConfig) []models.Article {
+	if config.SponsoredFilter == SponsoredOff {
+		return articles
+	}
+	patterns := config.SponsoredPatterns
+	if len(patterns) == 0 {
+		patterns = s.sponsoredPatterns
+	}
+	if len(patterns) == 0 {
+		return articles
+	}
+
+	kept := make([]models.Article, 0, len(articles))
+	var sponsored []models.Article
+	for _, article := range articles {
+		if isSponsored(article, patterns) {
+			sponsored = append(sponsored, article)
+		} else {
+			kept = append(kept, article)
+		}
+	}
+	if len(sponsored) == 0 {
+		return articles
+	}
+
+	if config.SponsoredFilter == SponsoredDemote {
+		log.Printf("Moved %d sponsored articles to the end for config %d", len(sponsored), config.ID)
+		return append(kept, sponsored...)
+	}
+	log.Printf("Dropped %d sponsored articles for config %d", len(sponsored), config.ID)
+	return kept
+}
+
+// Distribute keeps count articles from a candidate list, dividing the slots
+// between a configuration's feeds according to its distribution.
+//
+// Strategies:
+//   - "even": Every feed gets count/len(feedURLs) slots (at least one), so
+//     each feed is represented when it has articles
+//   - "priority": Slots are shared in proportion to each feed's priority
+//     (feeds without one count as 1), so favored feeds get more
+//   - "newest": No per-feed slots; the first count candidates are kept, and
+//     a high-volume feed may fill the whole dossier
+//
+// A feed's slots go to its earliest candidates. Slots a feed can't fill
+// (too few articles, or a failed fetch) and slots lost to rounding go to the
+// earliest remaining candidates from any feed, so count is reached whenever
+// there are enough candidates.
+//
+// Parameters:
+//   - articles: Candidates in preference order (SortArticles order)
+//   - count: Number of articles to keep
+//   - distribution: DistributionEven, DistributionPriority, or
+//     DistributionNewest ("" is treated as even)
+//   - feedURLs: The configuration's feeds, matched against Article.FeedURL
+//   - priorities: Feed priorities by URL (only read for "priority")
+//
+// Returns:
+//   - []models.Article: At most count articles, in candidate order
+func Distribute(articles []models.Article, count int, distribution string, feedURLs []string, priorities map[string]int) []models.Article {
+	if len(articles) <= count {
+		return articles
+	}
+	if distribution == DistributionNewest || len(feedURLs) == 0 {
+		return articles[:count]
+	}
 
-			// Build normalized article model
-			article := models.Article{
-				Title:       item.Title,
-				Link:        item.Link,
-				Description: item.Description,
-				Content:     content,
-				Author:      author,
-				PublishedAt: publishedAt,
+	weights := make(map[string]int, len(feedURLs))
+	total := 0
+	for _, feedURL := range feedURLs {
+		weight := 1
+		if distribution == DistributionPriority && priorities[feedURL] > 0 {
+			weight = priorities[feedURL]
+		}
+		weights[feedURL] = weight
+		total += weight
+	}
+	slots := make(map[string]int, len(weights))
+	for feedURL, weight := range weights {
+		slots[feedURL] = max(1, count*weight/total)
+	}
+
+	// First each feed's own slots, then whatever is left over, newest first
+	picked := make([]bool, len(articles))
+	remaining := count
+	for i, article := range articles {
+		if remaining == 0 {
+			break
+		}
+		if slots[article.FeedURL] > 0 {
+			slots[article.FeedURL]--
+			picked[i] = true
+			remaining--
+		}
+	}
+	for i := range articles {
+		if remaining == 0 {
+			break
+		}
+		if !picked[i] {
+			picked[i] = true
+			remaining--
+		}
+	}
+
+	selected := make([]models.Article, 0, count)
+	for i, article := range articles {
+		if picked[i] {
+			selected = append(selected, article)
+		}
+	}
+	return selected
+}
+
+// ValidDistribution reports whether mode is a distribution value.
+func ValidDistribution(mode string) bool {
+	return mode == DistributionEven || mode == DistributionPriority || mode == DistributionNewest
+}
+
+// ValidSponsoredFilter reports whether mode is a sponsored_filter value.
+func ValidSponsoredFilter(mode string) bool {
+	return mode == SponsoredDrop || mode == SponsoredDemote || mode == SponsoredOff
+}
+
+// CheckSponsoredPattern rejects a sponsored pattern that could never match
+// ("category:" with no category).
+//
+// Returns:
+//   - error: Why the pattern is unusable (nil if valid)
+func CheckSponsoredPattern(pattern string) error {
+	if strings.HasPrefix(strings.ToLower(pattern), sponsoredCategoryPrefix) &&
+		strings.TrimSpace(pattern[len(sponsoredCategoryPrefix):]) == "" {
+		return fmt.Errorf("%q needs a category name after it", sponsoredCategoryPrefix)
+	}
+	return nil
+}
+
+// isSponsored reports whether an article matches any sponsored pattern (see
+// FilterSponsored).
+func isSponsored(article models.Article, patterns []string) bool {
+	title := strings.ToLower(article.Title)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if category, ok := strings.CutPrefix(pattern, sponsoredCategoryPrefix); ok {
+			category = strings.TrimSpace(category)
+			for _, itemCategory := range article.Categories {
+				if strings.ToLower(strings.TrimSpace(itemCategory)) == category {
+					return true
+				}
 			}
+			continue
+		}
+		if pattern != "" && strings.Contains(title, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================================================================
+// TEXT NORMALIZATION
+// ============================================================================
 
-			feedArticles = append(feedArticles, article)
+// MediaEnclosure returns the most relevant media enclosure of a feed item.
+//
+// Podcast and video feeds attach their media via <enclosure> (RSS) or
+// rel="enclosure" links (Atom). Audio and video enclosures are preferred; any
+// other enclosure type is used only if nothing better exists.
+//
+// Parameters:
+//   - item: Parsed feed item
+//
+// Returns:
+//   - string: Enclosure URL (empty if the item has no enclosures)
+//   - string: Enclosure MIME type (may be empty if the feed omits it)
+func MediaEnclosure(item *gofeed.Item) (string, string) {
+	var fallbackURL, fallbackType string
+	for _, enclosure := range item.Enclosures {
+		if enclosure == nil || enclosure.URL == "" {
+			continue
+		}
+		if strings.HasPrefix(enclosure.Type, "audio/") || strings.HasPrefix(enclosure.Type, "video/") {
+			return enclosure.URL, enclosure.Type
+		}
+		if fallbackURL == "" {
+			fallbackURL, fallbackType = enclosure.URL, enclosure.Type
 		}
+	}
+	return fallbackURL, fallbackType
+}
 
-		allArticles = append(allArticles, feedArticles...)
-		log.Printf("Fetched %d articles from %s", len(feedArticles), feedURL)
+// DecodeEntities decodes HTML entities in feed text that is meant to be plain text.
+//
+// Many feeds double-encode entities, so titles arrive as "AT&amp;T" or
+// "&#8217;" and would otherwise be escaped a second time by the email
+// templates. Decoding is repeated until the text is stable (bounded by
+// maxEntityDecodePasses) to handle double encoding.
+//
+// Text that already contains HTML markup is returned unchanged: its entities
+// (e.g. "&lt;code&gt;" inside a <pre>) are meaningful HTML, and decoding them
+// could turn escaped text into live tags.
+//
+// Examples:
+//   - "AT&amp;T" → "AT&T"
+//   - "It&amp;#8217;s here" → "It’s here"
+//   - "<p>Tom &amp; Jerry</p>" → unchanged
+//
+// Parameters:
+//   - text: Raw feed text (title, description, or content)
+//
+// Returns:
+//   - string: Text with entities decoded
+func DecodeEntities(text string) string {
+	if htmlTagPattern.MatchString(text) {
+		return text
 	}
 
-	// Sort by published date (newest first) and limit to maxArticles
-	if len(allArticles) > maxArticles {
-		// Bubble sort by publication date (descending)
-		// Using bubble sort for simplicity - article counts are typically small (<100)
-		for i := 0; i < len(allArticles)-1; i++ {
-			for j := i + 1; j < len(allArticles); j++ {
-				if allArticles[i].PublishedAt.Before(allArticles[j].PublishedAt) {
-					allArticles[i], allArticles[j] = allArticles[j], allArticles[i]
+	for i := 0; i < maxEntityDecodePasses; i++ {
+		decoded := html.UnescapeString(text)
+		if decoded == text {
+			break
+		}
+		text = decoded
+	}
+	return text
+}
+
+// allowedHTMLTags lists the elements kept when sanitizing feed HTML for
+// display. Other elements are unwrapped (their text is kept) unless listed
+// in droppedHTMLTags.
+var allowedHTMLTags = map[string]bool{
+	"p": true, "br": true, "b": true, "strong": true, "i": true, "em": true, "u": true,
+	"a": true, "ul": true, "ol": true, "li": true, "blockquote": true, "code": true, "pre": true,
+}
+
+// droppedHTMLTags lists elements removed together with their contents.
+var droppedHTMLTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"form": true, "noscript": true, "svg": true, "template": true, "head": true, "title": true,
+}
+
+// blockHTMLTags start a new line in the plain-text version of feed HTML.
+var blockHTMLTags = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "blockquote": true, "pre": true, "tr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "section": true, "article": true,
+}
+
+// NormalizeItemText converts a feed item's description or content into a
+// plain-text version for AI prompts and a sanitized HTML version for display.
+//
+// Atom <content type="xhtml">, CDATA-wrapped HTML, and entity-escaped HTML
+// all reach gofeed's item fields as markup. Left as-is, the tags become noise
+// in prompts and show up literally in emails, which escape the description.
+//
+// Sanitizing keeps basic formatting (paragraphs, emphasis, lists, and http(s)
+// or mailto links) and drops all attributes except link targets; scripts,
+// styles, and embeds are removed along with their contents.
+//
+// Parameters:
+//   - raw: Item text as parsed from the feed
+//
+// Returns:
+//   - plain: Text with markup removed, whitespace collapsed, and entities decoded
+//   - sanitized: Display-safe HTML ("" when raw contains no markup)
+func NormalizeItemText(raw string) (plain, sanitized string) {
+	raw = strings.TrimSpace(raw)
+	if !htmlTagPattern.MatchString(raw) {
+		return DecodeEntities(raw), ""
+	}
+
+	parent := &nethtml.Node{Type: nethtml.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := nethtml.ParseFragment(strings.NewReader(raw), parent)
+	if err != nil {
+		// The HTML5 parser accepts any input; keep the text rather than fail
+		return DecodeEntities(htmlTagPattern.ReplaceAllString(raw, " ")), ""
+	}
+
+	var text, markup strings.Builder
+	for _, node := range nodes {
+		writeItemNode(node, &text, &markup)
+	}
+
+	lines := strings.Split(text.String(), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	plain = strings.Join(kept, "\n")
+	if plain == "" {
+		return "", ""
+	}
+	return plain, strings.TrimSpace(markup.String())
+}
+
+// writeItemNode renders one parsed node into the plain-text and sanitized
+// HTML builders used by NormalizeItemText.
+func writeItemNode(node *nethtml.Node, text, markup *strings.Builder) {
+	switch node.Type {
+	case nethtml.TextNode:
+		text.WriteString(node.Data)
+		markup.WriteString(html.EscapeString(node.Data))
+		return
+	case nethtml.ElementNode, nethtml.DocumentNode:
+	default:
+		return
+	}
+
+	tag := strings.ToLower(node.Data)
+	if droppedHTMLTags[tag] {
+		return
+	}
+	if blockHTMLTags[tag] {
+		text.WriteString("\n")
+	}
+
+	keep := allowedHTMLTags[tag]
+	if keep {
+		markup.WriteString("<" + tag)
+		if tag == "a" {
+			for _, attr := range node.Attr {
+				if strings.ToLower(attr.Key) != "href" {
+					continue
+				}
+				if link, err := url.Parse(strings.TrimSpace(attr.Val)); err == nil &&
+					(link.Scheme == "http" || link.Scheme == "https" || link.Scheme == "mailto") {
+					markup.WriteString(` href="` + html.EscapeString(link.String()) + `"`)
 				}
 			}
 		}
-		// Limit to requested count
-		allArticles = allArticles[:maxArticles]
+		markup.WriteString(">")
+	}
+	if tag == "br" {
+		return
 	}
 
-	log.Printf("Total articles fetched: %d", len(allArticles))
-	return allArticles, nil
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeItemNode(child, text, markup)
+	}
+
+	if keep {
+		markup.WriteString("</" + tag + ">")
+	}
+	if blockHTMLTags[tag] {
+		text.WriteString("\n")
+	}
 }
@@ -0,0 +1,34 @@
+package rss
+
+import "testing"
+
+func TestDecodeEntities(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain text", "Markets rally", "Markets rally"},
+		{"named", "AT&amp;T &quot;deal&quot; &lt;pending&gt;", `AT&T "deal" <pending>`},
+		{"named without semicolon", "Tom &amp Jerry", "Tom & Jerry"},
+		{"named typographic", "Caf&eacute; &ndash; Paris&hellip;", "Café – Paris…"},
+		{"numeric", "It&#8217;s &#169; 2025", "It’s © 2025"},
+		{"hex", "It&#x2019;s &#X263A;", "It’s ☺"},
+		{"double-encoded ampersand", "AT&amp;amp;T", "AT&T"},
+		{"double-encoded numeric", "It&amp;#8217;s here", "It’s here"},
+		{"triple-encoded", "Q&amp;amp;amp;A", "Q&A"},
+		{"unknown entity kept", "Price &bogus; rises", "Price &bogus; rises"},
+		{"bare ampersand kept", "Salt & pepper", "Salt & pepper"},
+		{"markup unchanged", "<p>Tom &amp; Jerry</p>", "<p>Tom &amp; Jerry</p>"},
+		{"escaped markup inside tags unchanged", "<pre>&lt;code&gt;</pre>", "<pre>&lt;code&gt;</pre>"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecodeEntities(tt.text); got != tt.want {
+				t.Errorf("DecodeEntities(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/geraldfingburke/dossier/server/internal/database"
+	"github.com/geraldfingburke/dossier/server/internal/models"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// FEED PRE-FETCHING
+// ============================================================================
+
+const (
+	// minPrefetchInterval is the shortest accepted FEED_PREFETCH_INTERVAL, so
+	// the pre-fetcher can't hammer publishers
+	minPrefetchInterval = 5 * time.Minute
+
+	// defaultPrefetchRetention is how far back pooled articles are used and
+	// kept when FEED_PREFETCH_RETENTION is not set
+	defaultPrefetchRetention = 7 * 24 * time.Hour
+)
+
+// prefetchLoop keeps the articles pool filled while the scheduler runs.
+//
+// With FEED_PREFETCH_INTERVAL set, every feed referenced by an active
+// configuration is fetched on that interval (and once at startup) and its
+// items stored in the articles table, deduplicated by link. Scheduled
+// generations then read from this pool, so a feed that is briefly down at a
+// config's delivery time still contributes the articles fetched before.
+//
+// Parameters:
+//   - stop: Closed by Stop to end the loop
+func (s *Service) prefetchLoop(stop <-chan struct{}) {
+	log.Printf("Scheduler: Pre-fetching feeds every %s (retention %s)", s.prefetchInterval, s.prefetchRetention)
+	ticker := time.NewTicker(s.prefetchInterval)
+	defer ticker.Stop()
+
+	for {
+		s.prefetchActiveFeeds()
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// prefetchActiveFeeds runs one pre-fetch pass over the feeds of all active,
+// non-rollup configurations, then prunes articles older than the retention.
+// Each pass is bounded by the pre-fetch interval so passes never overlap.
+func (s *Service) prefetchActiveFeeds() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.prefetchInterval)
+	defer cancel()
+
+	configs, err := s.getActiveDossierConfigs()
+	if err != nil {
+		log.Printf("Scheduler: Pre-fetch could not load configs: %v", err)
+		return
+	}
+
+	seen := make(map[string]bool)
+	var feedURLs []string
+	for i := range configs {
+		if configs[i].IsRollup() {
+			continue
+		}
+		urls, err := database.ResolveFeedURLs(ctx, s.db, &configs[i])
+		if err != nil {
+			log.Printf("Scheduler: Pre-fetch could not resolve feeds for config %d: %v", configs[i].ID, err)
+			continue
+		}
+		for _, feedURL := range urls {
+			if !seen[feedURL] {
+				seen[feedURL] = true
+				feedURLs = append(feedURLs, feedURL)
+			}
+		}
+	}
+
+	stored := s.prefetchFeeds(ctx, feedURLs)
+	log.Printf("Scheduler: Pre-fetched %d articles from %d feeds", stored, len(feedURLs))
+
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM articles WHERE feed_url IS NOT NULL AND published_at < $1
+	`, s.now().Add(-s.prefetchRetention))
+	if err != nil {
+		log.Printf("Scheduler: Failed to prune article pool: %v", err)
+	} else if pruned, _ := result.RowsAffected(); pruned > 0 {
+		log.Printf("Scheduler: Pruned %d pooled articles older than %s", pruned, s.prefetchRetention)
+	}
+}
+
+// prefetchFeeds fetches feeds into the articles pool. Failed feeds are
+// logged and skipped; their earlier articles stay in the pool.
+//
+// Parameters:
+//   - ctx: Context bounding the whole pass
+//   - feedURLs: Feeds to fetch
+//
+// Returns:
+//   - int: Articles stored or refreshed
+func (s *Service) prefetchFeeds(ctx context.Context, feedURLs []string) int {
+	stored := 0
+	for _, feedURL := range feedURLs {
+		if ctx.Err() != nil {
+			log.Printf("Scheduler: Pre-fetch ran out of time; pooled articles are used for the remaining feeds")
+			break
+		}
+		articles, err := s.rssService.FetchFeedArticles(ctx, feedURL)
+		if err != nil {
+			log.Printf("Error pre-fetching feed %s: %v", feedURL, err)
+			continue
+		}
+		count, err := s.storeArticles(ctx, feedURL, articles)
+		if err != nil {
+			log.Printf("Error storing articles from %s: %v", feedURL, err)
+		}
+		stored += count
+	}
+	return stored
+}
+
+// storeArticles upserts a feed's articles into the pool by link. A refetched
+// article keeps its original published_at, so items without a date in the
+// feed don't look new on every pass.
+//
+// Parameters:
+//   - ctx: Context for the queries
+//   - feedURL: Feed the articles came from (linked to feeds.id when shared)
+//   - articles: Articles to store
+//
+// Returns:
+//   - int: Articles stored before any error
+//   - error: Database error
+func (s *Service) storeArticles(ctx context.Context, feedURL string, articles []models.Article) (int, error) {
+	stored := 0
+	for _, article := range articles {
+		if article.Link == "" || article.Title == "" {
+			continue
+		}
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO articles (feed_id, feed_url, title, link, description, description_html,
+				content, author, media_url, media_type, published_at, categories)
+			VALUES ((SELECT id FROM feeds WHERE url = $1), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (link) DO UPDATE SET
+				feed_url = EXCLUDED.feed_url, title = EXCLUDED.title,
+				description = EXCLUDED.description, description_html = EXCLUDED.description_html,
+				content = EXCLUDED.content, author = EXCLUDED.author,
+				media_url = EXCLUDED.media_url, media_type = EXCLUDED.media_type,
+				categories = EXCLUDED.categories
+		`, feedURL, article.Title, article.Link, article.Description, article.DescriptionHTML,
+			article.Content, article.Author, article.MediaURL, article.MediaType, article.PublishedAt,
+			pq.Array(article.Categories))
+		if err != nil {
+			return stored, err
+		}
+		stored++
+	}
+
+	if _, err := s.db.ExecContext(ctx, `UPDATE feeds SET last_fetched = NOW() WHERE url = $1`, feedURL); err != nil {
+		log.Printf("Error updating last_fetched for %s: %v", feedURL, err)
+	}
+	return stored, nil
+}
+
+// pooledArticles loads a configuration's articles from the pool: items from
+// its feeds published within the retention window, newest first.
+//
+// Parameters:
+//   - ctx: Context for the query
+//   - feedURLs: The configuration's resolved feed URLs
+//
+// Returns:
+//   - []models.Article: Pooled articles, newest first (at most articleCeiling)
+//   - error: Database error
+func (s *Service) pooledArticles(ctx context.Context, feedURLs []string) ([]models.Article, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, COALESCE(feed_id, 0), title, link, COALESCE(description, ''),
+			COALESCE(description_html, ''), COALESCE(content, ''), COALESCE(author, ''),
+			COALESCE(media_url, ''), COALESCE(media_type, ''), published_at, created_at,
+			COALESCE(categories, '{}'), feed_url
+		FROM articles
+		WHERE feed_url = ANY($1) AND published_at >= $2
+		ORDER BY published_at DESC, link
+		LIMIT $3
+	`, pq.Array(feedURLs), s.now().Add(-s.prefetchRetention), s.articleCeiling)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var articles []models.Article
+	for rows.Next() {
+		var article models.Article
+		err := rows.Scan(&article.ID, &article.FeedID, &article.Title, &article.Link, &article.Description,
+			&article.DescriptionHTML, &article.Content, &article.Author,
+			&article.MediaURL, &article.MediaType, &article.PublishedAt, &article.CreatedAt,
+			pq.Array(&article.Categories), &article.FeedURL)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, article)
+	}
+	return articles, rows.Err()
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/geraldfingburke/dossier/server/internal/database"
+	"github.com/geraldfingburke/dossier/server/internal/models"
+)
+
+// ============================================================================
+// RESENDING FAILED DELIVERIES
+// ============================================================================
+
+// ErrNotResendable is returned by ResendDelivery for a delivery that was
+// already sent, is being resent, or has no stored content (skipped runs, and
+// runs that failed before their summary was generated).
+var ErrNotResendable = errors.New("delivery cannot be resent")
+
+// UnsentDossierError is returned when a dossier was generated but its email
+// could not be sent. It carries the generated content, which
+// RecordFailedDelivery stores on the failed delivery so ResendDelivery can
+// send it later without fetching feeds or calling the model again.
+type UnsentDossierError struct {
+	Summary  string           // Generated summary HTML
+	Articles []models.Article // Articles the email lists (nil for rollups)
+	Err      error            // Send failure
+}
+
+// Error returns the send failure's message.
+func (e *UnsentDossierError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the send failure, so errors.Is still finds
+// email.ErrEmailFailed.
+func (e *UnsentDossierError) Unwrap() error { return e.Err }
+
+// ResendDelivery sends a failed delivery's stored dossier again, for runs
+// whose summary was generated but whose email failed (SMTP down, bad
+// credentials since fixed). Nothing is fetched or generated; the stored
+// summary and articles are rendered with the configuration's current
+// recipient and email settings.
+//
+// Concurrency:
+// The row is marked sent before the email goes out, conditional on it still
+// being unsent, so two concurrent resends cannot both send. A failed send
+// puts it back with the new error.
+//
+// On success the row becomes a normal delivery: email_sent is true, the error
+// is cleared, and delivery_date is now (so since_last_delivery counts from
+// the resend). If the scheduler has since delivered a later run for the same
+// period, resending still sends this older edition as well.
+//
+// Parameters:
+//   - ctx: Context for the database queries
+//   - deliveryID: Failed delivery to resend
+//
+// Returns:
+//   - error: Delivery or configuration not found, inactive configuration,
+//     ErrNotResendable, or the send failure
+func (s *Service) ResendDelivery(ctx context.Context, deliveryID int) error {
+	var configID int
+	var summary string
+	var articlesJSON []byte
+	err := s.db.QueryRowContext(ctx, `
+		SELECT config_id, summary, articles
+		FROM dossier_deliveries
+		WHERE id = $1 AND email_sent = false AND error_message IS NOT NULL AND articles IS NOT NULL
+	`, deliveryID).Scan(&configID, &summary, &articlesJSON)
+	if err == sql.ErrNoRows {
+		var exists bool
+		if err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM dossier_deliveries WHERE id = $1)`, deliveryID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("delivery %d not found", deliveryID)
+		}
+		return fmt.Errorf("%w: delivery %d was sent or has no stored content", ErrNotResendable, deliveryID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load delivery: %w", err)
+	}
+
+	var articles []models.Article
+	if err := json.Unmarshal(articlesJSON, &articles); err != nil {
+		return fmt.Errorf("failed to decode stored articles: %w", err)
+	}
+
+	var config models.DossierConfig
+	err = database.ScanConfig(s.db.QueryRowContext(ctx, `
+		SELECT `+database.ConfigColumns+`
+		FROM dossier_configs WHERE id = $1
+	`, configID), &config)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("dossier configuration %d not found", configID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	// An inactive config may have been unsubscribed from an email link
+	if !config.Active {
+		return fmt.Errorf("dossier configuration %d is inactive; activate it to resend", configID)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE dossier_deliveries SET email_sent = true
+		WHERE id = $1 AND email_sent = false
+	`, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to claim delivery: %w", err)
+	}
+	if claimed, err := result.RowsAffected(); err != nil || claimed == 0 {
+		return fmt.Errorf("%w: delivery %d is already being resent", ErrNotResendable, deliveryID)
+	}
+
+	if err := s.emailService.SendDossier(ctx, &config, summary, articles); err != nil {
+		if _, dbErr := s.db.Exec(`
+			UPDATE dossier_deliveries SET email_sent = false, error_message = $2 WHERE id = $1
+		`, deliveryID, err.Error()); dbErr != nil {
+			log.Printf("Error recording failed resend of delivery %d: %v", deliveryID, dbErr)
+		}
+		return err
+	}
+
+	if _, err := s.db.Exec(`
+		UPDATE dossier_deliveries SET error_message = NULL, delivery_date = $2 WHERE id = $1
+	`, deliveryID, s.now()); err != nil {
+		log.Printf("Error recording resend of delivery %d: %v", deliveryID, err)
+	}
+
+	log.Printf("Resent delivery %d for config %d (%s) to %s", deliveryID, config.ID, config.Title, config.Email)
+	return nil
+}
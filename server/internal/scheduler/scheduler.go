@@ -37,6 +37,9 @@
 // The scheduler is designed for safe concurrent operation:
 //   - Single ticker goroutine checks schedules
 //   - Each dossier generation runs in separate goroutine
+//   - Concurrent generations bounded by SCHEDULER_MAX_CONCURRENT
+//   - At most one in-flight generation per configuration
+//   - Each generation is tracked as an in-memory job (queued/running/succeeded/failed)
 //   - Thread-safe start/stop via mutex
 //   - Graceful shutdown via stop channel
 //
@@ -65,9 +68,9 @@
 //
 // # Performance Characteristics
 //
-//   - Check frequency: 1 minute (configurable via ticker duration)
+//   - Check frequency: 1 minute (checkInterval)
 //   - Dossier generation: Async (doesn't block other deliveries)
-//   - Context timeout: 10 minutes per dossier
+//   - Context timeout: GENERATION_TIMEOUT per dossier (default: 10 minutes)
 //   - Database queries: Minimal (one query per check cycle)
 //
 // # Usage Example
@@ -81,22 +84,72 @@ package scheduler
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/geraldfingburke/dossier/server/internal/ai"
+	"github.com/geraldfingburke/dossier/server/internal/database"
 	"github.com/geraldfingburke/dossier/server/internal/email"
 	"github.com/geraldfingburke/dossier/server/internal/models"
 	"github.com/geraldfingburke/dossier/server/internal/rss"
-	"github.com/lib/pq"
 )
 
 // ============================================================================
 // SERVICE DEFINITION
 // ============================================================================
 
+const (
+	// defaultMaxConcurrentGenerations bounds simultaneous dossier generations when
+	// SCHEDULER_MAX_CONCURRENT is not set. Generation is LLM-bound, so a small
+	// number keeps Ollama responsive.
+	defaultMaxConcurrentGenerations = 2
+
+	// checkInterval is how often the ticker evaluates configuration schedules
+	checkInterval = 1 * time.Minute
+
+	// defaultDeliveryTolerance is how long after the scheduled time a missed
+	// delivery may still be sent when DELIVERY_WINDOW_TOLERANCE is not set
+	defaultDeliveryTolerance = 2 * time.Minute
+
+	// defaultSandboxInterval is the gap between sandbox deliveries when
+	// SANDBOX_INTERVAL is not set
+	defaultSandboxInterval = 15 * time.Minute
+
+	// defaultGenerationTimeout is the budget for one scheduled generation
+	// (fetch, summarize, send) when GENERATION_TIMEOUT is not set
+	defaultGenerationTimeout = 10 * time.Minute
+
+	// defaultFetchTimeout is the share of the generation budget that feed
+	// fetching may use when GENERATION_FETCH_TIMEOUT is not set
+	defaultFetchTimeout = 2 * time.Minute
+
+	// defaultMaxGenerationArticles caps the articles one generation holds
+	// before per-source caps and the article count apply, when
+	// GENERATION_MAX_ARTICLES is not set. Large feeds times many feeds would
+	// otherwise all be aggregated in memory.
+	defaultMaxGenerationArticles = 500
+
+	// rollupDeliveryCount is how many of the source config's most recent
+	// deliveries a rollup config summarizes
+	rollupDeliveryCount = 7
+
+	// maxRetainedJobs caps how many finished generation jobs are kept in memory
+	// for status queries. Oldest finished jobs are evicted first.
+	maxRetainedJobs = 200
+
+	// adminNotifyCooldown is the minimum gap between failure notifications to
+	// ADMIN_NOTIFY_EMAIL. Failures inside the window are counted and reported
+	// with the next notification instead of each sending its own email.
+	adminNotifyCooldown = 15 * time.Minute
+)
+
 // Service handles scheduled dossier generation and delivery.
 //
 // The service maintains a ticker that periodically checks for dossiers
@@ -117,15 +170,140 @@ import (
 //   - stopChan: Channel for graceful shutdown signaling
 //   - mutex: Read-write mutex for thread-safe state management
 //   - running: Current running state of the scheduler
+//   - generationSlots: Semaphore bounding concurrent generations
+//   - inFlight: Configuration IDs with a generation currently queued or running
+//   - nextCheck: When the ticker is next expected to fire (zero when stopped)
+//   - jobs: Generation jobs by ID (queued, running, and recently finished)
+//   - jobOrder: Job IDs in creation order, used for eviction
+//   - nextJobID: Counter used to assign job IDs
+//   - adminNotifyEmail: Address notified when a generation fails (empty disables)
+//   - notifySkipped: Also notify adminNotifyEmail when a delivery is skipped
+//   - lastAdminNotify: When the last failure notification was sent
+//   - suppressedFailures: Failures not notified individually due to throttling
+//   - stateMutex: Mutex protecting inFlight, nextCheck, job, and notification state
+//   - deliveryTolerance: How late after its delivery time a dossier may still be sent
+//   - sandboxInterval: Gap between deliveries for configs in sandbox mode
+//   - generationTimeout: Overall budget for one generation
+//   - fetchTimeout: Part of generationTimeout that feed fetching may use
+//   - articleCeiling: Most articles one generation aggregates
+//   - prefetchInterval: How often the background pre-fetcher runs (0 disables it)
+//   - prefetchRetention: How long pre-fetched articles stay in the pool
+//   - prefetchStop: Closed by Stop to end the pre-fetch loop
+//   - now: Clock used for every scheduling decision (time.Now outside tests)
 type Service struct {
-	db           *sql.DB
-	rssService   *rss.Service
-	aiService    *ai.Service
-	emailService *email.Service
-	ticker       *time.Ticker
-	stopChan     chan bool
-	mutex        sync.RWMutex
-	running      bool
+	db                 *sql.DB
+	rssService         *rss.Service
+	aiService          *ai.Service
+	emailService       *email.Service
+	ticker             *time.Ticker
+	stopChan           chan bool
+	mutex              sync.RWMutex
+	running            bool
+	generationSlots    chan struct{}
+	inFlight           map[int]bool
+	nextCheck          time.Time
+	jobs               map[string]*GenerationJob
+	jobOrder           []string
+	nextJobID          int64
+	adminNotifyEmail   string
+	notifySkipped      bool
+	lastAdminNotify    time.Time
+	suppressedFailures int
+	stateMutex         sync.Mutex
+	deliveryTolerance  time.Duration
+	sandboxInterval    time.Duration
+	generationTimeout  time.Duration
+	fetchTimeout       time.Duration
+	articleCeiling     int
+	prefetchInterval   time.Duration
+	prefetchRetention  time.Duration
+	prefetchStop       chan struct{}
+	now                func() time.Time
+}
+
+// StatusSnapshot is a point-in-time view of the scheduler's state.
+//
+// Fields:
+//   - Running: Whether the ticker loop is active
+//   - NextCheck: When schedules will next be evaluated (nil when stopped)
+//   - ActiveConfigs: Number of active dossier configurations
+//   - InFlightGenerations: Generations currently queued or running
+//   - MaxConcurrent: Concurrency limit for generations
+type StatusSnapshot struct {
+	Running             bool
+	NextCheck           *time.Time
+	ActiveConfigs       int
+	InFlightGenerations int
+	MaxConcurrent       int
+}
+
+// JobStatus is the lifecycle state of a generation job.
+type JobStatus string
+
+// Generation job states. Jobs move queued → running → succeeded|failed.
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// GenerationJob tracks one asynchronous dossier generation.
+//
+// Jobs are held in memory only; they do not survive a restart. Values
+// returned by the service are copies and safe to read without locking.
+//
+// Fields:
+//   - ID: Job identifier returned to callers
+//   - ConfigID: Configuration being generated
+//   - Status: Current lifecycle state
+//   - Error: Failure message (empty unless Status is failed)
+//   - Err: The failure itself, for matching sentinel errors (nil unless failed)
+//   - CreatedAt: When the job was queued
+//   - StartedAt: When generation began (nil while queued)
+//   - FinishedAt: When generation completed (nil until finished)
+type GenerationJob struct {
+	ID         string
+	ConfigID   int
+	Status     JobStatus
+	Error      string
+	Err        error
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// generationRun carries per-run options through the generation pipeline.
+type generationRun struct {
+	force     bool          // Bypass the AI summary cache
+	sender    dossierSender // Delivers the email
+	periodKey string        // Scheduled period to claim ("" for manual runs)
+	sandbox   bool          // Scheduled sandbox delivery; consumes one of the config's sandbox sends
+	claimID   int           // Delivery row claimed for periodKey (set by the pipeline)
+	since     time.Time     // Articles published at or before this are dropped (since_last_delivery; zero for none)
+}
+
+// dossierSender delivers a rendered dossier. *email.Service opens a
+// connection per message; *email.Batch reuses one across several.
+type dossierSender interface {
+	SendDossier(ctx context.Context, config *models.DossierConfig, summary string, articles []models.Article) error
+}
+
+// ErrGenerationInProgress is returned when a configuration already has a
+// generation queued or running, whether started by the scheduler or manually.
+var ErrGenerationInProgress = errors.New("generation already in progress")
+
+// TriggerSummary reports the outcome of triggering generation for a batch of
+// configurations.
+//
+// Fields:
+//   - Total: Number of active configurations considered
+//   - Triggered: Configurations queued for generation
+//   - Skipped: Configurations skipped because a generation was already in flight
+type TriggerSummary struct {
+	Total     int
+	Triggered int
+	Skipped   int
 }
 
 // ============================================================================
@@ -137,6 +315,29 @@ type Service struct {
 // The service is created in a stopped state. Call Start() to begin
 // the scheduling loop.
 //
+// Environment Variables:
+//   - SCHEDULER_MAX_CONCURRENT: Maximum simultaneous dossier generations (default: 2)
+//   - ADMIN_NOTIFY_EMAIL: Address that receives failure notifications (optional)
+//   - ADMIN_NOTIFY_SKIPPED: "true" to also notify ADMIN_NOTIFY_EMAIL when a
+//     delivery is skipped for having too few articles (default: disabled)
+//   - DELIVERY_WINDOW_TOLERANCE: How late after its delivery time a dossier may
+//     still be sent, as a Go duration (default: 2m, minimum: 1m)
+//   - SANDBOX_INTERVAL: Gap between deliveries for configs in sandbox mode, as
+//     a Go duration (default: 15m, minimum: 1m)
+//   - GENERATION_TIMEOUT: Overall budget for one generation, as a Go duration
+//     (default: 10m)
+//   - GENERATION_FETCH_TIMEOUT: Part of that budget feed fetching may use
+//     before generation continues with the feeds fetched so far (default: 2m,
+//     at most half of GENERATION_TIMEOUT)
+//   - GENERATION_MAX_ARTICLES: Most articles one generation aggregates across
+//     all its feeds, shared evenly between them, regardless of the config's
+//     article count (default: 500)
+//   - FEED_PREFETCH_INTERVAL: How often feeds of active configs are fetched
+//     into the articles pool in the background, as a Go duration (default:
+//     unset, disabled; minimum: 5m). See prefetch.go
+//   - FEED_PREFETCH_RETENTION: How far back pooled articles are used and kept,
+//     as a Go duration (default: 168h)
+//
 // Parameters:
 //   - db: Database connection for querying configs and recording deliveries
 //   - rssService: Service for fetching RSS feed articles
@@ -152,13 +353,106 @@ type Service struct {
 //	scheduler.Start()
 //	defer scheduler.Stop()
 func NewService(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emailService *email.Service) *Service {
+	maxConcurrent := defaultMaxConcurrentGenerations
+	if value := os.Getenv("SCHEDULER_MAX_CONCURRENT"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			maxConcurrent = parsed
+		} else {
+			log.Printf("Invalid SCHEDULER_MAX_CONCURRENT %q, using default %d", value, defaultMaxConcurrentGenerations)
+		}
+	}
+
+	deliveryTolerance := defaultDeliveryTolerance
+	if value := os.Getenv("DELIVERY_WINDOW_TOLERANCE"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= 0 {
+			deliveryTolerance = parsed
+		} else {
+			log.Printf("Invalid DELIVERY_WINDOW_TOLERANCE %q, using default %s", value, defaultDeliveryTolerance)
+		}
+	}
+	// Narrower than one tick and a delivery could fall between two checks
+	if deliveryTolerance < checkInterval {
+		deliveryTolerance = checkInterval
+	}
+
+	sandboxInterval := defaultSandboxInterval
+	if value := os.Getenv("SANDBOX_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= checkInterval {
+			sandboxInterval = parsed
+		} else {
+			log.Printf("Invalid SANDBOX_INTERVAL %q, using default %s", value, defaultSandboxInterval)
+		}
+	}
+
+	generationTimeout := defaultGenerationTimeout
+	if value := os.Getenv("GENERATION_TIMEOUT"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			generationTimeout = parsed
+		} else {
+			log.Printf("Invalid GENERATION_TIMEOUT %q, using default %s", value, defaultGenerationTimeout)
+		}
+	}
+
+	fetchTimeout := defaultFetchTimeout
+	if value := os.Getenv("GENERATION_FETCH_TIMEOUT"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			fetchTimeout = parsed
+		} else {
+			log.Printf("Invalid GENERATION_FETCH_TIMEOUT %q, using default %s", value, defaultFetchTimeout)
+		}
+	}
+	// Fetching is a sub-budget; it must leave time for summarizing and sending
+	if fetchTimeout > generationTimeout/2 {
+		fetchTimeout = generationTimeout / 2
+	}
+
+	articleCeiling := defaultMaxGenerationArticles
+	if value := os.Getenv("GENERATION_MAX_ARTICLES"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			articleCeiling = parsed
+		} else {
+			log.Printf("Invalid GENERATION_MAX_ARTICLES %q, using default %d", value, defaultMaxGenerationArticles)
+		}
+	}
+
+	var prefetchInterval time.Duration
+	if value := os.Getenv("FEED_PREFETCH_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= minPrefetchInterval {
+			prefetchInterval = parsed
+		} else {
+			log.Printf("Invalid FEED_PREFETCH_INTERVAL %q (minimum %s), pre-fetching disabled", value, minPrefetchInterval)
+		}
+	}
+
+	prefetchRetention := defaultPrefetchRetention
+	if value := os.Getenv("FEED_PREFETCH_RETENTION"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			prefetchRetention = parsed
+		} else {
+			log.Printf("Invalid FEED_PREFETCH_RETENTION %q, using default %s", value, defaultPrefetchRetention)
+		}
+	}
+
 	return &Service{
-		db:           db,
-		rssService:   rssService,
-		aiService:    aiService,
-		emailService: emailService,
-		stopChan:     make(chan bool),
-		running:      false,
+		db:                db,
+		rssService:        rssService,
+		aiService:         aiService,
+		emailService:      emailService,
+		stopChan:          make(chan bool),
+		running:           false,
+		generationSlots:   make(chan struct{}, maxConcurrent),
+		inFlight:          make(map[int]bool),
+		jobs:              make(map[string]*GenerationJob),
+		adminNotifyEmail:  strings.TrimSpace(os.Getenv("ADMIN_NOTIFY_EMAIL")),
+		notifySkipped:     os.Getenv("ADMIN_NOTIFY_SKIPPED") == "true",
+		deliveryTolerance: deliveryTolerance,
+		sandboxInterval:   sandboxInterval,
+		generationTimeout: generationTimeout,
+		fetchTimeout:      fetchTimeout,
+		articleCeiling:    articleCeiling,
+		prefetchInterval:  prefetchInterval,
+		prefetchRetention: prefetchRetention,
+		now:               time.Now,
 	}
 }
 
@@ -174,6 +468,9 @@ func NewService(db *sql.DB, rssService *rss.Service, aiService *ai.Service, emai
 //  3. Evaluates each configuration's schedule
 //  4. Triggers async dossier generation for due deliveries
 //
+// When FEED_PREFETCH_INTERVAL is set, a second goroutine keeps the articles
+// pool filled (see prefetchLoop).
+//
 // Thread Safety:
 // Safe to call concurrently. If already running, logs and returns.
 // Uses mutex to prevent multiple scheduler instances.
@@ -200,13 +497,15 @@ func (s *Service) Start() {
 
 	log.Println("Starting dossier scheduler...")
 	s.running = true
-	s.ticker = time.NewTicker(1 * time.Minute) // Check every minute
+	s.ticker = time.NewTicker(checkInterval)
+	s.setNextCheck(s.now().Add(checkInterval))
 
 	go func() {
 		for {
 			select {
 			case <-s.ticker.C:
-				log.Printf("Scheduler: Ticker fired at %s", time.Now().UTC().Format("15:04:05"))
+				log.Printf("Scheduler: Ticker fired at %s", s.now().UTC().Format("15:04:05"))
+				s.setNextCheck(s.now().Add(checkInterval))
 				s.checkAndProcessDossiers()
 			case <-s.stopChan:
 				return
@@ -214,6 +513,11 @@ func (s *Service) Start() {
 		}
 	}()
 
+	if s.prefetchInterval > 0 {
+		s.prefetchStop = make(chan struct{})
+		go s.prefetchLoop(s.prefetchStop)
+	}
+
 	log.Println("Dossier scheduler started successfully")
 }
 
@@ -252,6 +556,11 @@ func (s *Service) Stop() {
 	s.running = false
 	s.ticker.Stop()
 	s.stopChan <- true
+	if s.prefetchStop != nil {
+		close(s.prefetchStop)
+		s.prefetchStop = nil
+	}
+	s.setNextCheck(time.Time{})
 	log.Println("Dossier scheduler stopped")
 }
 
@@ -278,6 +587,87 @@ func (s *Service) IsRunning() bool {
 	return s.running
 }
 
+// GenerationTimeout returns the overall budget for one generation
+// (GENERATION_TIMEOUT), so manual runs outside the scheduler can use the
+// same deadline as scheduled ones.
+//
+// Returns:
+//   - time.Duration: Budget for fetching, summarizing, and sending one dossier
+func (s *Service) GenerationTimeout() time.Duration {
+	return s.generationTimeout
+}
+
+// Settings reports the scheduler's effective configuration for the
+// serverConfig query, starting with its fixed schedule check interval.
+//
+// Returns:
+//   - []models.Setting: Effective values
+func (s *Service) Settings() []models.Setting {
+	prefetch := "disabled"
+	if s.prefetchInterval > 0 {
+		prefetch = s.prefetchInterval.String()
+	}
+	return []models.Setting{
+		{Name: "Schedule check interval (fixed)", Value: checkInterval.String()},
+		{Name: "SCHEDULER_MAX_CONCURRENT", Value: strconv.Itoa(cap(s.generationSlots))},
+		{Name: "GENERATION_TIMEOUT", Value: s.generationTimeout.String()},
+		{Name: "GENERATION_FETCH_TIMEOUT", Value: s.fetchTimeout.String()},
+		{Name: "GENERATION_MAX_ARTICLES", Value: strconv.Itoa(s.articleCeiling)},
+		{Name: "FEED_PREFETCH_INTERVAL", Value: prefetch},
+		{Name: "ADMIN_NOTIFY_EMAIL", Value: s.adminNotifyEmail},
+	}
+}
+
+// Snapshot returns a consistent view of the scheduler's current state.
+//
+// The active configuration count is read from the database first; the
+// in-memory state (running flag, next check, in-flight generations) is then
+// read while holding both the control and state mutexes so the values
+// describe the same instant.
+//
+// Parameters:
+//   - ctx: Context for the active-config count query
+//
+// Returns:
+//   - *StatusSnapshot: Scheduler state
+//   - error: Database query error
+//
+// Usage:
+// Backs the GraphQL schedulerStatus query.
+func (s *Service) Snapshot(ctx context.Context) (*StatusSnapshot, error) {
+	var activeCount int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM dossier_configs WHERE active = true
+	`).Scan(&activeCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count active configs: %w", err)
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+
+	snapshot := &StatusSnapshot{
+		Running:             s.running,
+		ActiveConfigs:       activeCount,
+		InFlightGenerations: len(s.inFlight),
+		MaxConcurrent:       cap(s.generationSlots),
+	}
+	if s.running && !s.nextCheck.IsZero() {
+		nextCheck := s.nextCheck
+		snapshot.NextCheck = &nextCheck
+	}
+	return snapshot, nil
+}
+
+// setNextCheck records when the ticker is next expected to fire.
+func (s *Service) setNextCheck(t time.Time) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	s.nextCheck = t
+}
+
 // ============================================================================
 // CONFIGURATION MANAGEMENT
 // ============================================================================
@@ -300,7 +690,7 @@ func (s *Service) IsRunning() bool {
 //   - Schedule evaluation results
 //   - Generation triggers and completion
 func (s *Service) checkAndProcessDossiers() {
-	log.Printf("Scheduler: Checking for dossiers to process at %s", time.Now().UTC().Format("15:04:05"))
+	log.Printf("Scheduler: Checking for dossiers to process at %s", s.now().UTC().Format("15:04:05"))
 
 	configs, err := s.getActiveDossierConfigs()
 	if err != nil {
@@ -310,6 +700,17 @@ func (s *Service) checkAndProcessDossiers() {
 
 	log.Printf("Scheduler: Found %d active configurations", len(configs))
 
+	// With SMTP_BATCH_SEND, everything due this minute shares one SMTP
+	// connection, closed once the last of these generations finishes
+	now := s.now()
+	var sender dossierSender = s.emailService
+	var batch *email.Batch
+	var pending sync.WaitGroup
+	if s.emailService != nil && s.emailService.BatchSendEnabled() {
+		batch = s.emailService.NewBatch()
+		sender = batch
+	}
+
 	for _, config := range configs {
 		log.Printf("Scheduler: Checking config %d (%s) - delivery_time: %s", config.ID, config.Title, config.DeliveryTime)
 
@@ -317,15 +718,318 @@ func (s *Service) checkAndProcessDossiers() {
 			log.Printf("Scheduler: Triggering dossier generation for config %d (%s)", config.ID, config.Title)
 
 			// Launch async generation to avoid blocking other configs
-			go func(cfg models.DossierConfig) {
-				if err := s.generateAndSendDossier(cfg); err != nil {
-					log.Printf("Error generating dossier for config %d (%s): %v", cfg.ID, cfg.Title, err)
-				}
-			}(config)
+			run := generationRun{sender: sender, periodKey: deliveryPeriodKey(config, now), sandbox: config.SandboxSends > 0}
+			s.dispatchGeneration(config, run, &pending)
 		} else {
 			log.Printf("Scheduler: Not time to generate dossier for config %d (%s)", config.ID, config.Title)
 		}
 	}
+
+	if batch != nil {
+		go func() {
+			pending.Wait()
+			batch.Close()
+		}()
+	}
+}
+
+// GenerateAllActive immediately queues generation for every active configuration.
+//
+// This bypasses schedule evaluation entirely and is intended for validating
+// changes (new model, edited configs) without waiting for delivery times.
+// Generations run asynchronously through the same concurrency-limited
+// dispatcher as scheduled runs, so configurations that already have a
+// generation in flight (scheduled or manual) are skipped rather than sent twice.
+//
+// Returns:
+//   - *TriggerSummary: Counts of considered, triggered, and skipped configs
+//   - error: Database query error
+func (s *Service) GenerateAllActive() (*TriggerSummary, error) {
+	configs, err := s.getActiveDossierConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &TriggerSummary{Total: len(configs)}
+	for _, config := range configs {
+		if s.dispatchGeneration(config, generationRun{sender: s.emailService}, nil) {
+			summary.Triggered++
+		} else {
+			summary.Skipped++
+		}
+	}
+
+	log.Printf("Scheduler: Manually triggered %d of %d active configurations (%d already in flight)",
+		summary.Triggered, summary.Total, summary.Skipped)
+	return summary, nil
+}
+
+// dispatchGeneration launches asynchronous generation for a configuration.
+//
+// Parameters:
+//   - config: Configuration to generate
+//   - run: Sender and scheduled period for the run
+//   - pending: Tracks the generation until it finishes (may be nil)
+//
+// Returns:
+//   - bool: true if generation was queued, false if already in flight
+func (s *Service) dispatchGeneration(config models.DossierConfig, run generationRun, pending *sync.WaitGroup) bool {
+	if _, err := s.enqueueGeneration(config, run, pending); err != nil {
+		log.Printf("Scheduler: Generation already in flight for config %d (%s), skipping", config.ID, config.Title)
+		return false
+	}
+	return true
+}
+
+// ============================================================================
+// GENERATION JOBS
+// ============================================================================
+
+// EnqueueGeneration queues asynchronous generation for a configuration and
+// returns the job tracking it.
+//
+// The configuration is marked in flight before the goroutine starts so that
+// overlapping triggers (ticker, batch, manual) cannot queue it twice. The
+// goroutine then waits for a free generation slot, bounding concurrency, and
+// records progress on the job as it runs.
+//
+// Parameters:
+//   - config: Configuration to generate
+//   - force: Bypass the AI summary cache and always regenerate
+//
+// Returns:
+//   - GenerationJob: Snapshot of the newly queued job
+//   - error: ErrGenerationInProgress if the configuration is already in flight
+func (s *Service) EnqueueGeneration(config models.DossierConfig, force bool) (GenerationJob, error) {
+	return s.enqueueGeneration(config, generationRun{force: force, sender: s.emailService}, nil)
+}
+
+// enqueueGeneration is EnqueueGeneration with full run options. When
+// pending is non-nil it is incremented for the queued job and released when
+// the job finishes, so a caller can tell when a whole batch is done.
+func (s *Service) enqueueGeneration(config models.DossierConfig, run generationRun, pending *sync.WaitGroup) (GenerationJob, error) {
+	if !s.TryBeginGeneration(config.ID) {
+		return GenerationJob{}, ErrGenerationInProgress
+	}
+
+	s.stateMutex.Lock()
+	s.nextJobID++
+	job := &GenerationJob{
+		ID:        strconv.FormatInt(s.nextJobID, 10),
+		ConfigID:  config.ID,
+		Status:    JobQueued,
+		CreatedAt: s.now(),
+	}
+	s.jobs[job.ID] = job
+	s.jobOrder = append(s.jobOrder, job.ID)
+	s.pruneJobsLocked()
+	queued := *job
+	s.stateMutex.Unlock()
+
+	if pending != nil {
+		pending.Add(1)
+	}
+	go func(cfg models.DossierConfig, jobID string) {
+		if pending != nil {
+			defer pending.Done()
+		}
+		defer s.EndGeneration(cfg.ID)
+
+		// Wait for a free generation slot
+		s.generationSlots <- struct{}{}
+		defer func() { <-s.generationSlots }()
+
+		s.updateJob(jobID, JobRunning, nil)
+		err := s.generateAndSendDossier(cfg, run)
+		if err != nil {
+			log.Printf("Error generating dossier for config %d (%s): %v", cfg.ID, cfg.Title, err)
+			s.updateJob(jobID, JobFailed, err)
+			s.notifyAdminOfFailure(cfg, err)
+			return
+		}
+		s.updateJob(jobID, JobSucceeded, nil)
+	}(config, job.ID)
+
+	return queued, nil
+}
+
+// GenerateNow runs generation and delivery for a configuration synchronously.
+//
+// This is the same pipeline scheduled runs use (including rollups, skip rules,
+// min_articles, and articles_only_fallback), without queueing a job. It
+// serves the synchronous generateAndSendDossier mutation and command-line
+// runs, where the caller wants to block until the dossier is sent.
+//
+// Parameters:
+//   - config: Configuration to generate
+//   - force: Bypass the AI summary cache and always regenerate
+//
+// Returns:
+//   - error: ErrGenerationInProgress if already in flight, or any step failure
+func (s *Service) GenerateNow(config models.DossierConfig, force bool) error {
+	if !s.TryBeginGeneration(config.ID) {
+		return ErrGenerationInProgress
+	}
+	defer s.EndGeneration(config.ID)
+
+	return s.generateAndSendDossier(config, generationRun{force: force, sender: s.emailService})
+}
+
+// GetJob returns a snapshot of a generation job.
+//
+// Parameters:
+//   - id: Job ID returned by EnqueueGeneration
+//
+// Returns:
+//   - GenerationJob: Copy of the job's current state
+//   - bool: false if the job is unknown or has been evicted
+func (s *Service) GetJob(id string) (GenerationJob, bool) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return GenerationJob{}, false
+	}
+	return *job, true
+}
+
+// updateJob transitions a job to a new status, stamping start/finish times.
+func (s *Service) updateJob(id string, status JobStatus, jobErr error) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return
+	}
+
+	now := s.now()
+	job.Status = status
+	switch status {
+	case JobRunning:
+		job.StartedAt = &now
+	case JobSucceeded, JobFailed:
+		job.FinishedAt = &now
+		if jobErr != nil {
+			job.Error = jobErr.Error()
+			job.Err = jobErr
+		}
+	}
+}
+
+// notifyAdminOfFailure emails ADMIN_NOTIFY_EMAIL about a failed generation.
+//
+// Notifications are throttled to one per adminNotifyCooldown so that a shared
+// outage (e.g., Ollama down) failing every configuration at once produces a
+// single email. Failures suppressed during the cooldown are counted and
+// mentioned in the next notification that goes out.
+//
+// Parameters:
+//   - config: Configuration whose generation failed
+//   - failure: Error returned by the generation pipeline
+func (s *Service) notifyAdminOfFailure(config models.DossierConfig, failure error) {
+	if s.adminNotifyEmail == "" || s.emailService == nil {
+		return
+	}
+
+	now := s.now()
+	s.stateMutex.Lock()
+	if !s.lastAdminNotify.IsZero() && now.Sub(s.lastAdminNotify) < adminNotifyCooldown {
+		s.suppressedFailures++
+		s.stateMutex.Unlock()
+		return
+	}
+	suppressed := s.suppressedFailures
+	s.suppressedFailures = 0
+	s.lastAdminNotify = now
+	s.stateMutex.Unlock()
+
+	subject := fmt.Sprintf("Dossier delivery failed: %s", config.Title)
+	body := fmt.Sprintf("Dossier generation failed.\n\nConfig: %s (ID %d)\nTime: %s\nError: %v\n",
+		config.Title, config.ID, now.UTC().Format(time.RFC3339), failure)
+	if suppressed > 0 {
+		body += fmt.Sprintf("\n%d other failure(s) occurred since the previous notification and were not reported individually. Check the server logs for details.\n", suppressed)
+	}
+
+	if err := s.emailService.SendAdminNotification(s.adminNotifyEmail, subject, body); err != nil {
+		log.Printf("Failed to send admin failure notification: %v", err)
+	}
+}
+
+// notifyAdminOfSkip emails ADMIN_NOTIFY_EMAIL that a delivery was skipped,
+// when ADMIN_NOTIFY_SKIPPED is enabled. Skips happen at most once per config
+// per scheduled period, so these notices are not throttled.
+//
+// Parameters:
+//   - config: Configuration whose delivery was skipped
+//   - reason: Why the delivery was skipped
+func (s *Service) notifyAdminOfSkip(config models.DossierConfig, reason string) {
+	if !s.notifySkipped || s.adminNotifyEmail == "" || s.emailService == nil {
+		return
+	}
+
+	subject := fmt.Sprintf("Dossier delivery skipped: %s", config.Title)
+	body := fmt.Sprintf("A scheduled dossier was not sent.\n\nConfig: %s (ID %d)\nTime: %s\nReason: %s\n",
+		config.Title, config.ID, s.now().UTC().Format(time.RFC3339), reason)
+
+	if err := s.emailService.SendAdminNotification(s.adminNotifyEmail, subject, body); err != nil {
+		log.Printf("Failed to send admin skip notification: %v", err)
+	}
+}
+
+// pruneJobsLocked evicts the oldest finished jobs once more than
+// maxRetainedJobs are held. Queued and running jobs are never evicted.
+// Callers must hold stateMutex.
+func (s *Service) pruneJobsLocked() {
+	excess := len(s.jobOrder) - maxRetainedJobs
+	if excess <= 0 {
+		return
+	}
+
+	kept := s.jobOrder[:0]
+	for _, id := range s.jobOrder {
+		job := s.jobs[id]
+		if excess > 0 && job.FinishedAt != nil {
+			delete(s.jobs, id)
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	s.jobOrder = kept
+}
+
+// TryBeginGeneration marks a configuration as having a generation in flight.
+//
+// This is the per-configuration idempotency guard shared by scheduled runs,
+// batch triggers, and the manual GraphQL trigger. Callers that receive true
+// must call EndGeneration when the pipeline finishes.
+//
+// Parameters:
+//   - configID: Configuration about to be generated
+//
+// Returns:
+//   - bool: true if the caller acquired the guard, false if already in flight
+func (s *Service) TryBeginGeneration(configID int) bool {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+
+	if s.inFlight[configID] {
+		return false
+	}
+	s.inFlight[configID] = true
+	return true
+}
+
+// EndGeneration releases the in-flight guard acquired by TryBeginGeneration.
+//
+// Parameters:
+//   - configID: Configuration whose generation finished
+func (s *Service) EndGeneration(configID int) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	delete(s.inFlight, configID)
 }
 
 // getActiveDossierConfigs retrieves all active dossier configurations from database.
@@ -343,9 +1047,7 @@ func (s *Service) checkAndProcessDossiers() {
 //   - error: Database query error (nil on success)
 func (s *Service) getActiveDossierConfigs() ([]models.DossierConfig, error) {
 	rows, err := s.db.Query(`
-		SELECT id, title, email, feed_urls, article_count, frequency, 
-		       delivery_time, timezone, tone, language, special_instructions, 
-		       active, created_at, updated_at
+		SELECT ` + database.ConfigColumns + `
 		FROM dossier_configs 
 		WHERE active = true
 	`)
@@ -357,12 +1059,7 @@ func (s *Service) getActiveDossierConfigs() ([]models.DossierConfig, error) {
 	var configs []models.DossierConfig
 	for rows.Next() {
 		var config models.DossierConfig
-		err := rows.Scan(
-			&config.ID, &config.Title, &config.Email, pq.Array(&config.FeedURLs),
-			&config.ArticleCount, &config.Frequency, &config.DeliveryTime,
-			&config.Timezone, &config.Tone, &config.Language,
-			&config.SpecialInstructions, &config.Active, &config.CreatedAt, &config.UpdatedAt,
-		)
+		err := database.ScanConfig(rows, &config)
 		if err != nil {
 			log.Printf("Error scanning dossier config: %v", err)
 			continue
@@ -384,12 +1081,22 @@ func (s *Service) getActiveDossierConfigs() ([]models.DossierConfig, error) {
 //  2. Get current time in that timezone
 //  3. Parse delivery time from configuration
 //  4. Check if current time matches delivery window
-//  5. Apply frequency-based rules (daily/weekly/monthly)
-//  6. Check duplicate prevention logic
+//  5. Skip weekends and configured skip dates
+//  6. Apply frequency-based rules (daily/weekly/monthly)
+//  7. Check duplicate prevention logic
+//
+// Configurations in sandbox mode skip all of this and are due every
+// SANDBOX_INTERVAL instead (see shouldGenerateSandbox).
 //
 // Time Matching:
-// Delivery occurs when current hour and minute match configured time.
-// This provides 1-minute granularity (matching ticker frequency).
+// Delivery occurs within the window starting at the configured time's instant
+// for today and lasting DELIVERY_WINDOW_TOLERANCE (default 2 minutes), so a
+// tick delayed past the target minute (GC pause, busy host) still delivers.
+// Every tick inside the window matches; the frequency checks below see the
+// first delivery (or its period claim) and turn the later ticks away. The
+// window ends at midnight rather than carrying over into the next day.
+// Nonexistent and repeated wall-clock times on DST transition days are
+// resolved by scheduledInstant so each day has exactly one window.
 //
 // Timezone Handling:
 // Each configuration has its own timezone. If invalid, falls back
@@ -415,9 +1122,13 @@ func (s *Service) shouldGenerateDossier(config models.DossierConfig) bool {
 	}
 
 	// Get current time in configuration's timezone
-	now := time.Now().In(location)
+	now := s.now().In(location)
 	log.Printf("Scheduler: Current time in %s: %s", config.Timezone, now.Format("2006-01-02 15:04:05 MST"))
 
+	if config.SandboxSends > 0 {
+		return s.shouldGenerateSandbox(config, now)
+	}
+
 	// Parse delivery time - handle multiple formats for robustness
 	var deliveryTime time.Time
 
@@ -451,22 +1162,27 @@ func (s *Service) shouldGenerateDossier(config models.DossierConfig) bool {
 		deliveryTime = fullTime
 	}
 
-	// Create target time for today in the configuration's timezone
-	// The deliveryTime parsed above is timezone-naive (just hours and minutes)
-	// We need to create a specific time today in the user's timezone
-	targetTime := time.Date(
-		now.Year(), now.Month(), now.Day(),
-		deliveryTime.Hour(), deliveryTime.Minute(), 0, 0,
-		location,
-	)
+	// Resolve today's delivery instant in the configuration's timezone. The
+	// parsed deliveryTime is timezone-naive (just hours and minutes), and on
+	// DST transition days that wall-clock time may not exist or may occur twice
+	targetTime := scheduledInstant(now, deliveryTime.Hour(), deliveryTime.Minute(), location)
 
 	log.Printf("Scheduler: Target delivery time for config %d: %s (hour=%d, minute=%d)", 
 		config.ID, targetTime.Format("2006-01-02 15:04:05 MST"), targetTime.Hour(), targetTime.Minute())
 	log.Printf("Scheduler: Current time hour=%d, minute=%d; Target hour=%d, minute=%d", 
 		now.Hour(), now.Minute(), targetTime.Hour(), targetTime.Minute())
 
-	// Check if we're within the delivery window (current minute matches target minute)
-	if now.Hour() != targetTime.Hour() || now.Minute() != targetTime.Minute() {
+	// Check if we're within the delivery window starting at the target
+	// instant. Comparing instants rather than wall-clock hour/minute means a
+	// repeated fall-back hour matches only once.
+	if now.Before(targetTime) || now.After(targetTime.Add(s.deliveryTolerance)) {
+		return false
+	}
+
+	// Skipped days produce no delivery and no delivery record, so frequency
+	// checks simply resume on the next eligible day
+	if reason := skipReason(config, now); reason != "" {
+		log.Printf("Scheduler: Skipping config %d today (%s)", config.ID, reason)
 		return false
 	}
 
@@ -484,6 +1200,79 @@ func (s *Service) shouldGenerateDossier(config models.DossierConfig) bool {
 	}
 }
 
+// scheduledInstant resolves a wall-clock delivery time on now's calendar date
+// to a single instant, handling daylight saving transitions explicitly.
+//
+// DST Handling:
+//   - Skipped times ("spring forward", e.g. 02:30 when clocks jump from 02:00
+//     to 03:00): the delivery moves forward by the size of the gap (03:30),
+//     so the day still gets exactly one delivery
+//   - Repeated times ("fall back", e.g. 01:30 occurring twice): the earlier
+//     occurrence is used, so the second pass through the same wall-clock
+//     minute does not match again
+//
+// Parameters:
+//   - now: Current time in the configuration's timezone (supplies the date)
+//   - hour: Configured delivery hour (0-23)
+//   - minute: Configured delivery minute (0-59)
+//   - location: Configuration's timezone
+//
+// Returns:
+//   - time.Time: The unique delivery instant for now's date
+func scheduledInstant(now time.Time, hour, minute int, location *time.Location) time.Time {
+	target := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, location)
+
+	if target.Hour() != hour || target.Minute() != minute {
+		// time.Date may normalize a nonexistent wall-clock time to either side
+		// of the gap; shift by the size of the offset change so it lands after
+		_, offsetBefore := target.Zone()
+		_, offsetAfter := target.Add(3 * time.Hour).Zone()
+		if gap := time.Duration(offsetAfter-offsetBefore) * time.Second; gap > 0 {
+			target = target.Add(gap)
+		}
+		log.Printf("Scheduler: %02d:%02d does not exist on %s in %s (DST), delivering at %s",
+			hour, minute, now.Format("2006-01-02"), location, target.Format("15:04 MST"))
+		return target
+	}
+
+	// For an ambiguous time, time.Date may return either occurrence. Prefer
+	// the earliest instant that still shows the same wall-clock time.
+	for _, shift := range []time.Duration{time.Hour, 30 * time.Minute} {
+		earlier := target.Add(-shift)
+		if earlier.Day() == target.Day() && earlier.Hour() == hour && earlier.Minute() == minute {
+			return earlier
+		}
+	}
+
+	return target
+}
+
+// skipReason reports why a configuration should not deliver on now's date.
+//
+// Both checks use the calendar date in the configuration's timezone, so a
+// skip date of "2025-12-25" covers Christmas Day wherever the reader is.
+//
+// Parameters:
+//   - config: Dossier configuration
+//   - now: Current time in configuration's timezone
+//
+// Returns:
+//   - string: Human-readable reason, or "" if delivery is allowed
+func skipReason(config models.DossierConfig, now time.Time) string {
+	if config.SkipWeekends && (now.Weekday() == time.Saturday || now.Weekday() == time.Sunday) {
+		return "weekend"
+	}
+
+	today := now.Format("2006-01-02")
+	for _, date := range config.SkipDates {
+		if date == today {
+			return "skip date " + date
+		}
+	}
+
+	return ""
+}
+
 // shouldGenerateDaily checks if a daily dossier should be generated.
 //
 // Logic:
@@ -491,6 +1280,12 @@ func (s *Service) shouldGenerateDossier(config models.DossierConfig) bool {
 //   - Checks if already generated today (in config's timezone)
 //   - Compares dates in YYYY-MM-DD format
 //
+// Business Days:
+// A daily config with skip_weekends delivers Monday through Friday only.
+// Weekends are rejected by skipReason before this check runs, and since a
+// skipped day leaves no delivery record, Monday compares against Friday's
+// delivery like any other day; nothing counts the weekend as missed.
+//
 // Parameters:
 //   - config: Dossier configuration
 //   - now: Current time in configuration's timezone
@@ -519,17 +1314,28 @@ func (s *Service) shouldGenerateDaily(config models.DossierConfig, now time.Time
 // shouldGenerateWeekly checks if a weekly dossier should be generated.
 //
 // Logic:
-//   - Generates once per week on Mondays
-//   - Uses ISO week numbers for comparison
-//   - Timezone-aware (Monday in config's timezone)
+//   - Without delivery_weekdays: generates once per week on Mondays, using
+//     ISO week numbers for comparison
+//   - With delivery_weekdays: generates once on each listed day, using the
+//     last delivery's date for duplicate prevention (like daily)
+//   - Timezone-aware (weekday in config's timezone)
 //
 // Parameters:
 //   - config: Dossier configuration
 //   - now: Current time in configuration's timezone
 //
 // Returns:
-//   - bool: true if should generate (Monday and not generated this week)
+//   - bool: true if should generate (delivery day and not yet generated)
 func (s *Service) shouldGenerateWeekly(config models.DossierConfig, now time.Time) bool {
+	// Multi-day schedules ("MWF", business days) behave like daily on the
+	// selected days
+	if len(config.DeliveryWeekdays) > 0 {
+		if !deliversOnWeekday(config, now.Weekday()) {
+			return false
+		}
+		return s.shouldGenerateDaily(config, now)
+	}
+
 	// Only generate on Mondays
 	if now.Weekday() != time.Monday {
 		return false
@@ -553,6 +1359,42 @@ func (s *Service) shouldGenerateWeekly(config models.DossierConfig, now time.Tim
 	return thisWeek != lastWeek
 }
 
+// shouldGenerateSandbox checks if a sandbox-mode dossier should be generated.
+//
+// Logic:
+//   - Ignores frequency, delivery time, and skip days entirely
+//   - Due when nothing has been delivered or attempted within the last
+//     SANDBOX_INTERVAL: failed runs count too, so a failing sandbox config
+//     retries once per interval instead of on every tick
+//   - Each scheduled sandbox delivery uses up one of config.SandboxSends;
+//     the normal schedule resumes once they run out
+//
+// Parameters:
+//   - config: Dossier configuration with SandboxSends > 0
+//   - now: Current time in configuration's timezone
+//
+// Returns:
+//   - bool: true if the sandbox interval has elapsed since the last run
+func (s *Service) shouldGenerateSandbox(config models.DossierConfig, now time.Time) bool {
+	lastAttempt, err := s.getLastAttemptTime(config.ID)
+	if err != nil {
+		log.Printf("Error checking last attempt time for config %d: %v", config.ID, err)
+		return false // A sandbox send is not worth risking a duplicate
+	}
+	return lastAttempt == nil || now.Sub(*lastAttempt) >= s.sandboxInterval
+}
+
+// deliversOnWeekday reports whether a weekly configuration's
+// delivery_weekdays include the given day.
+func deliversOnWeekday(config models.DossierConfig, day time.Weekday) bool {
+	for _, weekday := range config.DeliveryWeekdays {
+		if time.Weekday(weekday) == day {
+			return true
+		}
+	}
+	return false
+}
+
 // shouldGenerateMonthly checks if a monthly dossier should be generated.
 //
 // Logic:
@@ -593,7 +1435,8 @@ func (s *Service) shouldGenerateMonthly(config models.DossierConfig, now time.Ti
 // getLastGeneratedTime retrieves the most recent delivery time for a configuration.
 //
 // This method queries the dossier_deliveries table to find the last time
-// a dossier was generated, used for duplicate prevention logic.
+// a dossier was generated, used for duplicate prevention logic. Failed runs
+// are ignored so the period is retried.
 //
 // Parameters:
 //   - configID: Configuration ID to check
@@ -605,7 +1448,7 @@ func (s *Service) getLastGeneratedTime(configID int) (*time.Time, error) {
 	var deliveryDate time.Time
 	err := s.db.QueryRow(`
 		SELECT delivery_date FROM dossier_deliveries 
-		WHERE config_id = $1 
+		WHERE config_id = $1 AND error_message IS NULL
 		ORDER BY delivery_date DESC 
 		LIMIT 1
 	`, configID).Scan(&deliveryDate)
@@ -620,6 +1463,71 @@ func (s *Service) getLastGeneratedTime(configID int) (*time.Time, error) {
 	return &deliveryDate, nil
 }
 
+// getLastAttemptTime retrieves the most recent run of a configuration,
+// failed runs included. Sandbox mode paces itself by it, so repeated
+// failures back off to SANDBOX_INTERVAL like successful deliveries.
+//
+// Parameters:
+//   - configID: Configuration ID to check
+//
+// Returns:
+//   - *time.Time: Last delivery or failed run (nil if never run)
+//   - error: Database error (nil on success or no rows)
+func (s *Service) getLastAttemptTime(configID int) (*time.Time, error) {
+	var deliveryDate time.Time
+	err := s.db.QueryRow(`
+		SELECT delivery_date FROM dossier_deliveries
+		WHERE config_id = $1
+		ORDER BY delivery_date DESC
+		LIMIT 1
+	`, configID).Scan(&deliveryDate)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // Never run
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &deliveryDate, nil
+}
+
+// DeliveryCutoff returns the publication time at or before which articles are
+// left out of a since_last_delivery configuration's next dossier: the time
+// its last dossier was sent.
+//
+// Unlike getLastGeneratedTime this only counts sent dossiers. Skipped runs
+// and period claims (including the current run's own claim) never delivered
+// their articles, so they must not move the window forward.
+//
+// Parameters:
+//   - config: Configuration being generated
+//
+// Returns:
+//   - time.Time: Cutoff, or the zero time when the option is off or nothing
+//     has been sent yet (every fetched article is used)
+//   - error: Database error
+func (s *Service) DeliveryCutoff(config models.DossierConfig) (time.Time, error) {
+	if !config.SinceLastDelivery {
+		return time.Time{}, nil
+	}
+
+	var lastSent time.Time
+	err := s.db.QueryRow(`
+		SELECT delivery_date FROM dossier_deliveries
+		WHERE config_id = $1 AND email_sent = true
+		ORDER BY delivery_date DESC
+		LIMIT 1
+	`, config.ID).Scan(&lastSent)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load last delivery for config %d: %w", config.ID, err)
+	}
+	return lastSent, nil
+}
+
 // ============================================================================
 // DOSSIER GENERATION PIPELINE
 // ============================================================================
@@ -628,111 +1536,277 @@ func (s *Service) getLastGeneratedTime(configID int) (*time.Time, error) {
 //
 // This method orchestrates all steps needed to create and deliver a dossier:
 //  1. Fetch articles from all configured RSS feeds
-//  2. Convert feed items to Article models
-//  3. Sort by publication date (newest first)
-//  4. Limit to requested article count
-//  5. Generate AI summary with specified tone and language
-//  6. Send formatted email to recipient
-//  7. Record delivery in database
+//  2. Filter, sort by publication date (newest first), and limit to the
+//     requested article count (see CollectArticles)
+//  3. Generate AI summary with specified tone and language
+//  4. Send formatted email to recipient
+//  5. Record delivery in database
 //
 // Context:
-// Uses 10-minute timeout to prevent indefinite hangs on slow operations.
+// Uses a GENERATION_TIMEOUT budget (default: 10 minutes) to prevent indefinite
+// hangs on slow operations.
 // This is generous enough for slow feeds and AI processing.
 //
+// Stage Deadlines:
+// Within that budget each stage has its own deadline, so one stuck stage
+// fails fast and frees the generation slot for other configurations:
+//   - Feed fetching: GENERATION_FETCH_TIMEOUT in total; feeds not reached
+//     in time are skipped
+//   - Article scraping: SCRAPE_TIMEOUT per article (AI service)
+//   - Model calls: AI_CALL_TIMEOUT per call (AI service)
+//
+// Each stage logs when it times out.
+//
 // Error Handling:
 //   - Individual feed failures: Logged, continue with other feeds
 //   - No articles found: Returns error, no email sent
-//   - AI generation failure: Returns error, no email sent
+//   - AI generation failure: Returns error, no email sent, unless the config
+//     enables articles_only_fallback, in which case the articles are sent
+//     with a placeholder in place of the summary
 //   - Email failure: Returns error, no delivery recorded
 //   - Recording failure: Logged only (email already sent)
 //
+// Every failure is also recorded as a failed delivery (RecordFailedDelivery)
+// so it shows up in the delivery history with its error.
+//
+// Configurations with split_by_category deliver one dossier per feed
+// category instead (see generateAndSendByCategory).
+//
 // Concurrency:
 // Designed to be called from goroutine (doesn't block caller).
 // Each configuration's generation is independent.
 //
+// Duplicate Delivery Guard:
+// Scheduled runs carry an idempotency key for their period (see
+// deliveryPeriodKey) and claim it in dossier_deliveries before starting.
+// The claim is completed when the dossier is sent or skipped and released
+// if the run fails, so the same period is never emailed twice, even across
+// restarts. Manual runs have no key and are not limited.
+//
 // Parameters:
 //   - config: Dossier configuration with all settings
+//   - run: Cache bypass, sender, and (for scheduled runs) the period to claim
 //
 // Returns:
 //   - error: Any step failure (nil on complete success)
-func (s *Service) generateAndSendDossier(config models.DossierConfig) error {
-	log.Printf("Generating scheduled dossier for config %d (%s)", config.ID, config.Title)
+func (s *Service) generateAndSendDossier(config models.DossierConfig, run generationRun) (err error) {
+	log.Printf("Generating dossier for config %d (%s)", config.ID, config.Title)
+	started := time.Now()
+	defer func() {
+		if err != nil {
+			s.RecordFailedDelivery(config.ID, err)
+		}
+	}()
+
+	// Scheduled runs claim their period before doing any work, so a restarted
+	// process or a second instance cannot deliver the same period twice
+	if run.periodKey != "" {
+		var claimed bool
+		if run.claimID, claimed, err = s.claimDeliveryPeriod(config.ID, run.periodKey); err != nil {
+			return fmt.Errorf("failed to claim delivery period: %w", err)
+		}
+		if !claimed {
+			log.Printf("Scheduler: Config %d (%s) already has a delivery for period %s, not sending again",
+				config.ID, config.Title, run.periodKey)
+			return nil
+		}
+		// A failed run gives the period back so a later tick can retry it
+		defer func() {
+			if err != nil {
+				s.releaseDeliveryClaim(run.claimID)
+			}
+		}()
+	}
 
 	// Create context with timeout for entire pipeline
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), s.generationTimeout)
 	defer cancel()
-
-	// Fetch and aggregate articles from all configured feeds
-	var allArticles []models.Article
-	for _, feedURL := range config.FeedURLs {
-		feed, err := s.rssService.FetchFeed(ctx, feedURL)
-		if err != nil {
-			log.Printf("Error fetching feed %s: %v", feedURL, err)
-			continue // Skip failed feeds, continue with others
+	defer func() {
+		if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil {
+			log.Printf("Scheduler: Config %d (%s) used its whole %s generation budget: %v",
+				config.ID, config.Title, s.generationTimeout, err)
 		}
+	}()
 
-		// Convert gofeed.Item to models.Article
-		for _, item := range feed.Items {
-			// Extract author name (may be nil)
-			author := ""
-			if item.Author != nil {
-				author = item.Author.Name
-			}
+	// Rollup configs summarize past deliveries instead of fetching feeds
+	if config.IsRollup() {
+		return s.generateAndSendRollup(ctx, config, run)
+	}
 
-			// Use parsed published date or current time
-			publishedAt := time.Now()
-			if item.PublishedParsed != nil {
-				publishedAt = *item.PublishedParsed
-			}
+	// Looked up once per run, so every category group of a split config
+	// shares the window even after the first group is recorded
+	if run.since, err = s.DeliveryCutoff(config); err != nil {
+		return err
+	}
 
-			// Build article model
-			article := models.Article{
-				Title:       item.Title,
-				Link:        item.Link,
-				Description: item.Description,
-				Author:      author,
-				PublishedAt: publishedAt,
-			}
+	// Split configs send one dossier per feed category
+	if config.SplitByCategory {
+		return s.generateAndSendByCategory(ctx, config, run)
+	}
 
-			// Prefer full content over description
-			if item.Content != "" {
-				article.Content = item.Content
-			}
+	// Fetch and aggregate articles from raw feed URLs and referenced shared feeds
+	feedURLs, err := database.ResolveFeedURLs(ctx, s.db, &config)
+	if err != nil {
+		return err
+	}
+	return s.generateAndSendFeeds(ctx, config, feedURLs, run, started)
+}
 
-			allArticles = append(allArticles, article)
+// CollectArticles fetches and prepares the articles for one dossier. It is
+// the only article path: scheduled runs, manual generation, and the previews
+// all call it, so the same configuration yields the same articles however it
+// is triggered.
+//
+// Steps:
+//  1. Fetch, under GENERATION_FETCH_TIMEOUT: from the pre-fetched pool when
+//     FEED_PREFETCH_INTERVAL is set, otherwise live via FetchArticlesFromFeeds,
+//     each feed contributing at most an even share of GENERATION_MAX_ARTICLES
+//  2. Keep articles published after since (when set)
+//  3. Sort newest first, so the same articles always produce the same dossier
+//     (and summary cache key)
+//  4. Drop or demote sponsored items (sponsored_filter)
+//  5. Cap articles per source (max_per_source)
+//  6. Keep article_count (or candidate_pool_size, when larger), divided
+//     between the feeds per the configuration's distribution (see
+//     rss.Distribute)
+//
+// Parameters:
+//   - ctx: Generation context; fetching gets its own deadline inside it
+//   - config: Configuration being generated
+//   - feedURLs: Feeds to build the dossier from
+//   - since: Publication cutoff (zero for none; see DeliveryCutoff)
+//
+// Returns:
+//   - []models.Article: Prepared articles (may be empty when everything
+//     fetched was filtered out)
+//   - error: rss.ErrNoArticles when nothing was fetched, or a database failure
+func (s *Service) CollectArticles(ctx context.Context, config models.DossierConfig, feedURLs []string, since time.Time) ([]models.Article, error) {
+	// Fetching gets its own deadline inside the overall budget, so a hung
+	// feed can't leave the AI stages without time
+	fetchCtx, cancelFetch := context.WithTimeout(ctx, s.fetchTimeout)
+	defer cancelFetch()
+
+	var articles []models.Article
+	var err error
+	if s.prefetchInterval > 0 {
+		// Generate from the pre-fetched pool, topped up with a fresh fetch,
+		// so a feed that's down right now still contributes earlier articles
+		s.prefetchFeeds(fetchCtx, feedURLs)
+		if articles, err = s.pooledArticles(ctx, feedURLs); err != nil {
+			return nil, fmt.Errorf("failed to load pooled articles: %w", err)
+		}
+	} else {
+		articles, err = s.rssService.FetchArticlesFromFeeds(fetchCtx, feedURLs, s.articleCeiling)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch articles: %w", err)
+		}
+		if fetchCtx.Err() != nil && ctx.Err() == nil {
+			log.Printf("Scheduler: Config %d (%s) fetch stage timed out after %s; continuing with %d articles from the feeds that finished",
+				config.ID, config.Title, s.fetchTimeout, len(articles))
 		}
 	}
+	if len(articles) == 0 {
+		return nil, rss.ErrNoArticles
+	}
 
-	// Validate we have articles to process
-	if len(allArticles) == 0 {
-		return fmt.Errorf("no articles found from any feeds")
+	// Nothing new since the last dossier leaves the caller an empty list to
+	// skip on (via min_articles), not a failure
+	if !since.IsZero() {
+		fetched := len(articles)
+		articles = rss.PublishedAfter(articles, since)
+		log.Printf("Scheduler: Config %d (%s) has %d of %d articles published since the last delivery (%s)",
+			config.ID, config.Title, len(articles), fetched, since.Format(time.RFC3339))
 	}
 
-	// Limit to requested count (RSS service should handle sorting)
-	if len(allArticles) > config.ArticleCount {
-		allArticles = allArticles[:config.ArticleCount]
+	rss.SortArticles(articles)
+	articles = s.rssService.FilterSponsored(articles, &config)
+	articles = rss.CapPerSource(articles, config.MaxPerSource)
+
+	// The AI service's selection targets the same article_count (via
+	// SummaryOptionsFromConfig), so every article kept here is summarized
+	var priorities map[string]int
+	if config.Distribution == rss.DistributionPriority {
+		if priorities, err = database.ResolveFeedPriorities(ctx, s.db, feedURLs); err != nil {
+			return nil, err
+		}
+	}
+	count := config.ArticleCount
+	if config.CandidatePoolSize > count {
+		// Selection narrows the pool to article_count during generation
+		count = config.CandidatePoolSize
 	}
+	return rss.Distribute(articles, count, config.Distribution, feedURLs, priorities), nil
+}
 
-	// Generate AI summary with configured tone and language
-	summary, err := s.aiService.GenerateSummary(
-		ctx,
-		allArticles,
-		config.Tone,
-		config.Language,
-		config.SpecialInstructions,
-	)
+// generateAndSendFeeds runs the feed pipeline for one dossier: collect
+// (see CollectArticles), min_articles check, summary, send, and record.
+//
+// Parameters:
+//   - ctx: Generation context (the run's overall budget)
+//   - config: Configuration to deliver
+//   - feedURLs: Feeds to build the dossier from
+//   - run: Cache bypass, sender, and the claimed period, if any
+//   - started: Start of the run, for the recorded duration
+//
+// Returns:
+//   - error: Any step failure (nil once sent or deliberately skipped)
+func (s *Service) generateAndSendFeeds(ctx context.Context, config models.DossierConfig, feedURLs []string, run generationRun, started time.Time) error {
+	allArticles, err := s.CollectArticles(ctx, config, feedURLs, run.since)
 	if err != nil {
-		return fmt.Errorf("failed to generate summary: %w", err)
+		return err
+	}
+
+	// Too few articles to be worth an email: record the skip so the period
+	// counts as handled, and don't send. A candidate pool larger than
+	// article_count is counted as the article_count it is narrowed to.
+	if found := min(len(allArticles), config.ArticleCount); found < config.MinArticles {
+		reason := fmt.Sprintf("only %d articles found, below min_articles (%d)", found, config.MinArticles)
+		log.Printf("Scheduler: Skipping config %d (%s): %s", config.ID, config.Title, reason)
+		if err := s.recordSkippedDelivery(run, config.ID, found, reason); err != nil {
+			log.Printf("Error recording skipped delivery: %v", err)
+		}
+		s.notifyAdminOfSkip(config, reason)
+		return nil
+	}
+
+	// Generate AI summary with configured tone and language
+	opts := ai.SummaryOptionsFromConfig(&config)
+	opts.Force = run.force
+	opts.GeneratedAt = s.now()
+	var summary string
+	var usage ai.UsageStats
+	result, err := s.aiService.GenerateSummary(ctx, allArticles, opts)
+	switch {
+	case err == nil:
+		if result.RefusalDetected {
+			log.Printf("Scheduler: Model refusal detected while generating config %d (%s)", config.ID, config.Title)
+		}
+		summary = result.HTML
+		usage = result.Usage
+		if result.Included != nil {
+			// The rest of the candidate pool is listed in the summary itself
+			allArticles = result.Included
+		}
+	case config.ArticlesOnlyFallback:
+		// The articles are still worth sending; deliver the links alone,
+		// article_count of them rather than a whole candidate pool
+		log.Printf("Scheduler: Summary failed for config %d (%s), sending articles only: %v", config.ID, config.Title, err)
+		summary = email.ArticlesOnlySummary
+		allArticles = allArticles[:min(len(allArticles), config.ArticleCount)]
+	default:
+		return err
 	}
 
 	// Send formatted email to recipient
-	err = s.emailService.SendDossier(&config, summary, allArticles)
+	err = run.sender.SendDossier(ctx, &config, summary, allArticles)
 	if err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+		return &UnsentDossierError{Summary: summary, Articles: allArticles, Err: err}
 	}
 
-	// Record successful delivery in database
-	err = s.recordDossierGeneration(config.ID, summary, len(allArticles))
+	// Record successful delivery in database. The recorded duration covers
+	// the whole run, fetching and sending included.
+	usage.Duration = time.Since(started)
+	err = s.recordDossierGeneration(run, config.ID, summary, len(allArticles), usage)
 	if err != nil {
 		log.Printf("Error recording dossier generation: %v", err)
 		// Don't return error here since email was sent successfully
@@ -744,23 +1818,387 @@ func (s *Service) generateAndSendDossier(config models.DossierConfig) error {
 	return nil
 }
 
+// generateAndSendByCategory delivers a configuration as one dossier per feed
+// category (see database.ResolveFeedGroups). Each group runs the regular feed
+// pipeline on its own feeds, under the title "<title> (<category>)", and is
+// recorded as its own delivery. A configuration whose feeds all fall into one
+// group is delivered as a single dossier under its own title.
+//
+// The groups share the run's generation budget. A scheduled run's period
+// claim (and sandbox send) goes to the first group that is sent or skipped;
+// later groups insert their own delivery rows.
+//
+// Once any group has been handled the run counts as done, so the period is
+// not retried and the groups that succeeded are not sent twice. Groups that
+// failed are then recorded as failed deliveries and reported to the admin.
+//
+// Parameters:
+//   - ctx: Generation context (the run's overall budget)
+//   - config: Configuration with SplitByCategory set
+//   - run: Cache bypass, sender, and the claimed period, if any
+//
+// Returns:
+//   - error: Joined group failures when no group was delivered
+func (s *Service) generateAndSendByCategory(ctx context.Context, config models.DossierConfig, run generationRun) error {
+	groups, err := database.ResolveFeedGroups(ctx, s.db, &config)
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return rss.ErrNoArticles
+	}
+	if len(groups) == 1 {
+		return s.generateAndSendFeeds(ctx, config, groups[0].URLs, run, time.Now())
+	}
+
+	log.Printf("Scheduler: Config %d (%s) sending %d category dossiers", config.ID, config.Title, len(groups))
+
+	var failures []error
+	handled := false
+	for _, group := range groups {
+		groupConfig := config
+		groupConfig.Title = fmt.Sprintf("%s (%s)", config.Title, group.Label())
+
+		if err := s.generateAndSendFeeds(ctx, groupConfig, group.URLs, run, time.Now()); err != nil {
+			log.Printf("Scheduler: Config %d category %q failed: %v", config.ID, group.Label(), err)
+			failures = append(failures, fmt.Errorf("category %q: %w", group.Label(), err))
+			continue
+		}
+		handled = true
+		run.claimID, run.sandbox = 0, false
+	}
+
+	if !handled {
+		return errors.Join(failures...)
+	}
+	for _, failure := range failures {
+		s.RecordFailedDelivery(config.ID, failure)
+		s.notifyAdminOfFailure(config, failure)
+	}
+	return nil
+}
+
+// generateAndSendRollup generates and delivers a "digest of digests" for a
+// rollup configuration.
+//
+// Instead of fetching feeds, this loads the most recent rollupDeliveryCount
+// deliveries of the referenced source configuration and asks the AI service
+// for a meta-summary of them. The result is emailed and recorded like any
+// other delivery (with an article count of zero).
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - config: Rollup configuration (RollupSourceID must be set)
+//   - run: Sender, batching, and the claimed period, if any
+//
+// Returns:
+//   - error: Missing source deliveries, AI, or email failure
+func (s *Service) generateAndSendRollup(ctx context.Context, config models.DossierConfig, run generationRun) error {
+	if !config.IsRollup() {
+		return fmt.Errorf("config %d is not a rollup configuration", config.ID)
+	}
+	started := time.Now()
+
+	deliveries, err := s.getRecentDeliveries(ctx, *config.RollupSourceID, rollupDeliveryCount)
+	if err != nil {
+		return fmt.Errorf("failed to load source deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return fmt.Errorf("no deliveries found for source config %d", *config.RollupSourceID)
+	}
+
+	opts := ai.SummaryOptionsFromConfig(&config)
+	opts.GeneratedAt = s.now()
+	result, err := s.aiService.GenerateRollupSummary(ctx, deliveries, opts)
+	if err != nil {
+		return err
+	}
+	if result.RefusalDetected {
+		log.Printf("Scheduler: Model refusal detected while generating rollup %d (%s)", config.ID, config.Title)
+	}
+	summary := result.HTML
+
+	err = run.sender.SendDossier(ctx, &config, summary, nil)
+	if err != nil {
+		return &UnsentDossierError{Summary: summary, Err: err}
+	}
+
+	usage := result.Usage
+	usage.Duration = time.Since(started)
+	err = s.recordDossierGeneration(run, config.ID, summary, 0, usage)
+	if err != nil {
+		log.Printf("Error recording rollup generation: %v", err)
+	}
+
+	log.Printf("Successfully generated and sent rollup for config %d (%s) from %d deliveries of config %d",
+		config.ID, config.Title, len(deliveries), *config.RollupSourceID)
+
+	return nil
+}
+
+// getRecentDeliveries loads a configuration's most recent deliveries, returned
+// oldest first so summaries read chronologically.
+//
+// Parameters:
+//   - ctx: Context for the query
+//   - configID: Configuration whose deliveries to load
+//   - limit: Maximum number of deliveries
+//
+// Returns:
+//   - []models.DossierDelivery: Deliveries in chronological order
+//   - error: Database query error
+func (s *Service) getRecentDeliveries(ctx context.Context, configID, limit int) ([]models.DossierDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, config_id, delivery_date, summary, article_count, email_sent, created_at
+		FROM dossier_deliveries
+		WHERE config_id = $1 AND skip_reason = '' AND error_message IS NULL
+		ORDER BY delivery_date DESC
+		LIMIT $2
+	`, configID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.DossierDelivery
+	for rows.Next() {
+		var delivery models.DossierDelivery
+		err := rows.Scan(&delivery.ID, &delivery.ConfigID, &delivery.DeliveryDate, &delivery.Summary,
+			&delivery.ArticleCount, &delivery.EmailSent, &delivery.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Reverse into chronological order
+	for i, j := 0, len(deliveries)-1; i < j; i, j = i+1, j-1 {
+		deliveries[i], deliveries[j] = deliveries[j], deliveries[i]
+	}
+	return deliveries, nil
+}
+
 // recordDossierGeneration records a successful dossier delivery in the database.
 //
 // This creates an audit trail of all deliveries and is used by the
 // duplicate prevention logic to track when dossiers were last generated.
 //
+// Scheduled runs complete the row they claimed for their period; other runs
+// insert a new row.
+//
 // Parameters:
+//   - run: Run being recorded (claimID set for scheduled runs)
 //   - configID: Configuration ID that generated this dossier
 //   - summary: AI-generated summary HTML
 //   - articleCount: Number of articles included
+//   - usage: Work the run did, stored alongside the delivery
+//
+// Returns:
+//   - error: Database insertion error (nil on success)
+func (s *Service) recordDossierGeneration(run generationRun, configID int, summary string, articleCount int, usage ai.UsageStats) error {
+	if run.sandbox {
+		s.consumeSandboxSend(configID)
+	}
+
+	log.Printf("Scheduler: Config %d used %d model calls (~%d tokens) and %d page scrapes in %s",
+		configID, usage.LLMCalls, usage.Tokens, usage.PagesScraped, usage.Duration.Round(time.Second))
+
+	if run.claimID != 0 {
+		_, err := s.db.Exec(`
+			UPDATE dossier_deliveries
+			SET delivery_date = $2, summary = $3, article_count = $4, email_sent = true, skip_reason = '',
+				llm_calls = $5, llm_tokens = $6, pages_scraped = $7, duration_ms = $8
+			WHERE id = $1
+		`, run.claimID, s.now(), summary, articleCount,
+			usage.LLMCalls, usage.Tokens, usage.PagesScraped, usage.Duration.Milliseconds())
+		return err
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO dossier_deliveries (config_id, delivery_date, summary, article_count, email_sent,
+			llm_calls, llm_tokens, pages_scraped, duration_ms)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, configID, s.now(), summary, articleCount, true,
+		usage.LLMCalls, usage.Tokens, usage.PagesScraped, usage.Duration.Milliseconds())
+
+	return err
+}
+
+// consumeSandboxSend uses up one sandbox delivery after a sandbox dossier was
+// sent, returning the configuration to its normal schedule after the last.
+// Errors are logged; the worst case is one extra sandbox delivery.
+//
+// Parameters:
+//   - configID: Configuration that was delivered
+func (s *Service) consumeSandboxSend(configID int) {
+	var remaining int
+	err := s.db.QueryRow(`
+		UPDATE dossier_configs SET sandbox_sends = GREATEST(sandbox_sends - 1, 0), updated_at = NOW()
+		WHERE id = $1
+		RETURNING sandbox_sends
+	`, configID).Scan(&remaining)
+	if err != nil {
+		log.Printf("Error updating sandbox sends for config %d: %v", configID, err)
+		return
+	}
+	if remaining == 0 {
+		log.Printf("Scheduler: Config %d finished its sandbox sends, resuming normal schedule", configID)
+	}
+}
+
+// recordSkippedDelivery records a scheduled run that was deliberately not
+// sent. The row keeps the period's duplicate prevention working (the run
+// counts as handled) but is excluded from history and rollups.
+//
+// Parameters:
+//   - run: Run being recorded (claimID set for scheduled runs)
+//   - configID: Configuration whose run was skipped
+//   - articleCount: Number of articles that were found
+//   - reason: Why the run was skipped
 //
 // Returns:
 //   - error: Database insertion error (nil on success)
-func (s *Service) recordDossierGeneration(configID int, summary string, articleCount int) error {
+func (s *Service) recordSkippedDelivery(run generationRun, configID, articleCount int, reason string) error {
+	if run.claimID != 0 {
+		_, err := s.db.Exec(`
+			UPDATE dossier_deliveries
+			SET delivery_date = $2, article_count = $3, skip_reason = $4
+			WHERE id = $1
+		`, run.claimID, s.now(), articleCount, reason)
+		return err
+	}
+
 	_, err := s.db.Exec(`
-		INSERT INTO dossier_deliveries (config_id, delivery_date, summary, article_count, email_sent)
-		VALUES ($1, $2, $3, $4, $5)
-	`, configID, time.Now(), summary, articleCount, true)
+		INSERT INTO dossier_deliveries (config_id, delivery_date, summary, article_count, email_sent, skip_reason)
+		VALUES ($1, $2, '', $3, false, $4)
+	`, configID, s.now(), articleCount, reason)
 
 	return err
 }
+
+// RecordFailedDelivery stores a delivery row for a run that failed, with the
+// error that stopped it, so failures appear in the delivery history rather
+// than only in the logs. The row carries no idempotency key and is ignored by
+// scheduling, so the period is still retried. Errors are logged.
+//
+// When the cause is an *UnsentDossierError (generated, but the email failed)
+// the summary and articles are stored too, so ResendDelivery can send them.
+//
+// Parameters:
+//   - configID: Configuration whose generation failed
+//   - cause: Error returned by the run
+func (s *Service) RecordFailedDelivery(configID int, cause error) {
+	var summary string
+	var articleCount int
+	var articlesJSON interface{} // NULL unless there is content to resend
+	var unsent *UnsentDossierError
+	if errors.As(cause, &unsent) {
+		articles := unsent.Articles
+		if articles == nil {
+			articles = []models.Article{}
+		}
+		encoded, err := json.Marshal(articles)
+		if err != nil {
+			log.Printf("Error encoding articles of unsent dossier for config %d: %v", configID, err)
+		} else {
+			summary, articleCount, articlesJSON = unsent.Summary, len(unsent.Articles), string(encoded)
+		}
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO dossier_deliveries (config_id, delivery_date, summary, article_count, email_sent, error_message, articles)
+		VALUES ($1, $2, $3, $4, false, $5, $6)
+	`, configID, s.now(), summary, articleCount, cause.Error(), articlesJSON)
+	if err != nil {
+		log.Printf("Error recording failed delivery for config %d: %v", configID, err)
+	}
+}
+
+// claimedDeliveryReason marks a period claim whose email has not been
+// confirmed sent yet. Like other skip reasons it keeps the row out of
+// history; it is cleared once the delivery is recorded. A row left with this
+// reason means the process stopped mid-delivery.
+const claimedDeliveryReason = "claimed; delivery not confirmed"
+
+// claimDeliveryPeriod reserves a scheduled period for a configuration by
+// inserting a placeholder delivery with the period's idempotency key. The
+// unique index on idempotency_key makes this atomic across processes.
+//
+// Parameters:
+//   - configID: Configuration being delivered
+//   - key: Idempotency key from deliveryPeriodKey
+//
+// Returns:
+//   - int: ID of the claimed delivery row
+//   - bool: false if the period was already claimed or delivered
+//   - error: Database error
+func (s *Service) claimDeliveryPeriod(configID int, key string) (int, bool, error) {
+	var id int
+	err := s.db.QueryRow(`
+		INSERT INTO dossier_deliveries (config_id, delivery_date, summary, article_count, email_sent, skip_reason, idempotency_key)
+		VALUES ($1, $2, '', 0, false, $3, $4)
+		ON CONFLICT (idempotency_key) DO NOTHING
+		RETURNING id
+	`, configID, s.now(), claimedDeliveryReason, key).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// releaseDeliveryClaim deletes a claim after a failed run so the period can
+// be retried. Errors are logged; a stuck claim only blocks that one period.
+//
+// Parameters:
+//   - claimID: Delivery row returned by claimDeliveryPeriod
+func (s *Service) releaseDeliveryClaim(claimID int) {
+	if _, err := s.db.Exec(`DELETE FROM dossier_deliveries WHERE id = $1 AND email_sent = false`, claimID); err != nil {
+		log.Printf("Error releasing delivery claim %d: %v", claimID, err)
+	}
+}
+
+// deliveryPeriodKey returns the idempotency key for a configuration's
+// scheduled delivery at now, in the configuration's timezone.
+//
+// Periods:
+//   - daily, and weekly with delivery_weekdays: the calendar date
+//   - weekly (Mondays): the ISO week
+//   - monthly: the calendar month
+//   - sandbox mode: the UTC minute of the tick
+//
+// Parameters:
+//   - config: Configuration being delivered
+//   - now: Time of the scheduler tick
+//
+// Returns:
+//   - string: Key such as "config-7:daily:2025-03-14"
+func deliveryPeriodKey(config models.DossierConfig, now time.Time) string {
+	// Sandbox deliveries are SANDBOX_INTERVAL apart, so the minute is unique
+	if config.SandboxSends > 0 {
+		return fmt.Sprintf("config-%d:sandbox:%s", config.ID, now.UTC().Format("2006-01-02T15:04"))
+	}
+
+	if location, err := time.LoadLocation(config.Timezone); err == nil {
+		now = now.In(location)
+	} else {
+		now = now.In(time.Local)
+	}
+
+	period := now.Format("2006-01-02")
+	switch config.Frequency {
+	case "weekly":
+		if len(config.DeliveryWeekdays) == 0 {
+			year, week := now.ISOWeek()
+			period = fmt.Sprintf("%d-W%02d", year, week)
+		}
+	case "monthly":
+		period = now.Format("2006-01")
+	}
+	return fmt.Sprintf("config-%d:%s:%s", config.ID, config.Frequency, period)
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/geraldfingburke/dossier/server/internal/models"
+)
+
+// ============================================================================
+// TEST DATABASE
+// ============================================================================
+
+// emptyDriver is a database/sql driver whose queries return no rows, so
+// schedule checks see a configuration that has never been delivered.
+type emptyDriver struct{}
+
+func (emptyDriver) Open(string) (driver.Conn, error) { return emptyConn{}, nil }
+
+type emptyConn struct{}
+
+func (emptyConn) Prepare(string) (driver.Stmt, error) { return emptyStmt{}, nil }
+func (emptyConn) Close() error                        { return nil }
+func (emptyConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+type emptyStmt struct{}
+
+func (emptyStmt) Close() error                               { return nil }
+func (emptyStmt) NumInput() int                              { return -1 }
+func (emptyStmt) Exec([]driver.Value) (driver.Result, error) { return driver.RowsAffected(0), nil }
+func (emptyStmt) Query([]driver.Value) (driver.Rows, error)  { return emptyRows{}, nil }
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return []string{"value"} }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+func init() {
+	sql.Register("scheduler-test-empty", emptyDriver{})
+}
+
+// newClockService returns a Service with no delivery history whose clock
+// always reads now.
+func newClockService(t *testing.T, now time.Time) *Service {
+	t.Helper()
+	db, err := sql.Open("scheduler-test-empty", "")
+	if err != nil {
+		t.Fatalf("opening test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &Service{
+		db:                db,
+		deliveryTolerance: defaultDeliveryTolerance,
+		now:               func() time.Time { return now },
+	}
+}
+
+// ============================================================================
+// DAYLIGHT SAVING TIME
+// ============================================================================
+
+func TestScheduledInstantDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		date         time.Time
+		hour, minute int
+		want         time.Time
+	}{
+		{
+			// 02:00 jumps to 03:00 on 2025-03-09; 02:30 moves forward by the gap
+			name: "spring forward gap",
+			date: time.Date(2025, time.March, 9, 12, 0, 0, 0, newYork),
+			hour: 2, minute: 30,
+			want: time.Date(2025, time.March, 9, 7, 30, 0, 0, time.UTC), // 03:30 EDT
+		},
+		{
+			// 01:30 occurs twice on 2025-11-02; the first (EDT) occurrence wins
+			name: "fall back repeated hour",
+			date: time.Date(2025, time.November, 2, 12, 0, 0, 0, newYork),
+			hour: 1, minute: 30,
+			want: time.Date(2025, time.November, 2, 5, 30, 0, 0, time.UTC), // 01:30 EDT
+		},
+		{
+			name: "ordinary day",
+			date: time.Date(2025, time.June, 10, 12, 0, 0, 0, newYork),
+			hour: 7, minute: 0,
+			want: time.Date(2025, time.June, 10, 11, 0, 0, 0, time.UTC), // 07:00 EDT
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scheduledInstant(tt.date, tt.hour, tt.minute, newYork)
+			if !got.Equal(tt.want) {
+				t.Errorf("scheduledInstant = %s, want %s", got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldGenerateDossierAcrossDST(t *testing.T) {
+	if _, err := time.LoadLocation("America/New_York"); err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		deliveryTime string
+		now          time.Time // Clock reading, in UTC
+		want         bool
+	}{
+		// Spring forward, 2025-03-09: 02:30 does not exist and becomes 03:30 EDT
+		{"spring forward before moved time", "02:30", time.Date(2025, time.March, 9, 7, 0, 0, 0, time.UTC), false},
+		{"spring forward at moved time", "02:30", time.Date(2025, time.March, 9, 7, 30, 0, 0, time.UTC), true},
+		{"spring forward within tolerance", "02:30", time.Date(2025, time.March, 9, 7, 31, 0, 0, time.UTC), true},
+		{"spring forward after tolerance", "02:30", time.Date(2025, time.March, 9, 7, 45, 0, 0, time.UTC), false},
+
+		// Fall back, 2025-11-02: 01:30 occurs at 05:30 UTC (EDT) and 06:30 UTC (EST)
+		{"fall back first occurrence", "01:30", time.Date(2025, time.November, 2, 5, 30, 0, 0, time.UTC), true},
+		{"fall back second occurrence", "01:30", time.Date(2025, time.November, 2, 6, 30, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newClockService(t, tt.now)
+			config := models.DossierConfig{
+				ID:           1,
+				Frequency:    "daily",
+				DeliveryTime: tt.deliveryTime,
+				Timezone:     "America/New_York",
+			}
+			if got := s.shouldGenerateDossier(config); got != tt.want {
+				t.Errorf("shouldGenerateDossier at %s = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}